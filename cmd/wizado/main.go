@@ -2,19 +2,27 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wattfource/wizado/internal/config"
+	"github.com/wattfource/wizado/internal/dbus"
+	"github.com/wattfource/wizado/internal/hooks"
 	"github.com/wattfource/wizado/internal/launcher"
 	"github.com/wattfource/wizado/internal/license"
 	"github.com/wattfource/wizado/internal/logging"
+	"github.com/wattfource/wizado/internal/sandbox"
 	"github.com/wattfource/wizado/internal/setup"
+	"github.com/wattfource/wizado/internal/setup/validate"
+	"github.com/wattfource/wizado/internal/state"
 	"github.com/wattfource/wizado/internal/sysinfo"
 	"github.com/wattfource/wizado/internal/telemetry"
 	"github.com/wattfource/wizado/internal/tui"
@@ -27,6 +35,9 @@ func main() {
 	// Initialize logging
 	logCfg := logging.DefaultConfig()
 	logCfg.Component = "wizado"
+	if sinks, err := logging.LoadSinks(); err == nil && len(sinks) > 0 {
+		logCfg.Sinks = sinks
+	}
 	logging.Init(logCfg)
 	
 	// Initialize telemetry
@@ -45,6 +56,8 @@ License required: $10 for 5 machines at https://wizado.app`,
 		Version: Version,
 		Run:     runLaunch,
 	}
+	rootCmd.Flags().Bool("sandbox", false, "Run Steam/gamescope as an isolated unprivileged user")
+	rootCmd.Flags().Bool("no-hooks", false, "Skip pre/post-launch hooks")
 
 	// Config command
 	configCmd := &cobra.Command{
@@ -53,6 +66,51 @@ License required: $10 for 5 machines at https://wizado.app`,
 		Run:   runConfig,
 	}
 
+	// Ps command
+	psCmd := &cobra.Command{
+		Use:   "ps",
+		Short: "List active wizado gaming sessions",
+		Run:   runPs,
+	}
+	psCmd.Flags().Bool("json", false, "Output as JSON")
+
+	// Reap command
+	reapCmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Clean up sessions left behind by a crash",
+		Run:   runReap,
+	}
+
+	// Hooks commands
+	hooksCmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage pre/post-launch hooks",
+	}
+	hooksListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print configured hooks",
+		Run:   runHooksList,
+	}
+	hooksRunCmd := &cobra.Command{
+		Use:   "run STAGE",
+		Short: "Run one hook stage (pre_launch, post_launch, or on_crash)",
+		Args:  cobra.ExactArgs(1),
+		Run:   runHooksRun,
+	}
+	hooksTestCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run every hook stage once, for debugging",
+		Run:   runHooksTest,
+	}
+	hooksCmd.AddCommand(hooksListCmd, hooksRunCmd, hooksTestCmd)
+
+	// D-Bus policy command
+	dbusPolicyCmd := &cobra.Command{
+		Use:   "dbus-policy",
+		Short: "Print the effective xdg-dbus-proxy session bus rules",
+		Run:   runDBusPolicy,
+	}
+
 	// Setup command
 	setupCmd := &cobra.Command{
 		Use:   "setup",
@@ -61,6 +119,19 @@ License required: $10 for 5 machines at https://wizado.app`,
 	}
 	setupCmd.Flags().BoolP("yes", "y", false, "Non-interactive mode")
 	setupCmd.Flags().Bool("dry-run", false, "Print what would be done without making changes")
+	setupCmd.Flags().Bool("uninstall", false, "Remove wizado-managed Hyprland/waybar config")
+	setupCmd.Flags().Bool("sign-modules", false, "Sign nvidia kernel modules with sbctl under Secure Boot")
+	setupCmd.Flags().String("rollback", "", "Roll back a previous setup run (most recent if no timestamp given)")
+	setupCmd.Flags().Lookup("rollback").NoOptDefVal = "latest"
+	setupCmd.Flags().Bool("keep-partial", false, "Don't auto-rollback if setup fails partway through")
+
+	// Doctor command
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Run post-install conformance probes (Vulkan, gamescope, PipeWire, ...)",
+		Run:   runDoctor,
+	}
+	doctorCmd.Flags().Bool("json", false, "Output results as JSON")
 
 	// Status command (for waybar)
 	statusCmd := &cobra.Command{
@@ -92,7 +163,37 @@ License required: $10 for 5 machines at https://wizado.app`,
 		Run:   runInfo,
 	}
 	infoCmd.Flags().Bool("json", false, "Output as JSON")
-	
+
+	// Sysinfo command - machine-readable equivalent of `info`, for scripts
+	// and monitoring rather than a human at a terminal
+	sysinfoCmd := &cobra.Command{
+		Use:   "sysinfo",
+		Short: "Print system diagnostics in a machine-readable format",
+		Run:   runSysinfo,
+	}
+	sysinfoCmd.Flags().String("format", "json", "Output format: json, yaml, or prom")
+
+	sysinfoSnapshotCmd := &cobra.Command{
+		Use:   "snapshot <dir>",
+		Short: "Capture a redacted support bundle (system.json, system.txt, and aux command output) into <dir>",
+		Args:  cobra.ExactArgs(1),
+		Run:   runSysinfoSnapshot,
+	}
+
+	sysinfoDiffCmd := &cobra.Command{
+		Use:   "diff <dir-a> <dir-b>",
+		Short: "Diff two snapshot directories made by `sysinfo snapshot`",
+		Args:  cobra.ExactArgs(2),
+		Run:   runSysinfoDiff,
+	}
+	sysinfoVerifyCmd := &cobra.Command{
+		Use:   "verify <dir>",
+		Short: "Check a snapshot directory's manifest.sig against its current contents",
+		Args:  cobra.ExactArgs(1),
+		Run:   runSysinfoVerify,
+	}
+	sysinfoCmd.AddCommand(sysinfoSnapshotCmd, sysinfoDiffCmd, sysinfoVerifyCmd)
+
 	// Logs command - new!
 	logsCmd := &cobra.Command{
 		Use:   "logs",
@@ -103,7 +204,72 @@ License required: $10 for 5 machines at https://wizado.app`,
 	logsCmd.Flags().Bool("session", false, "View latest session log")
 	logsCmd.Flags().Bool("clear", false, "Clear all logs")
 
-	rootCmd.AddCommand(configCmd, setupCmd, statusCmd, activateCmd, removeCmd, infoCmd, logsCmd)
+	// License command group - scripted/headless equivalents of the TUI's
+	// license screens, so install flows and CI images don't need a terminal
+	licenseCmd := &cobra.Command{
+		Use:   "license",
+		Short: "Manage the wizado license non-interactively",
+	}
+	licenseActivateCmd := &cobra.Command{
+		Use:   "activate",
+		Short: "Activate a license",
+		Run:   runLicenseActivate,
+	}
+	licenseActivateCmd.Flags().String("email", "", "Account email")
+	licenseActivateCmd.Flags().String("key", "", "License key")
+	licenseActivateCmd.MarkFlagRequired("email")
+	licenseActivateCmd.MarkFlagRequired("key")
+	licenseStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print license status",
+		Run:   runLicenseStatus,
+	}
+	licenseStatusCmd.Flags().Bool("json", false, "Output the full status as JSON")
+	licenseVerifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Force a re-check against the license API, bypassing the re-verify cadence",
+		Run:   runLicenseVerify,
+	}
+	licenseVerifyCmd.Flags().Bool("json", false, "Output the full status as JSON")
+	licenseClearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the stored license",
+		Run:   runLicenseClear,
+	}
+	licenseImportCmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Install a license blob from a file",
+		Args:  cobra.ExactArgs(1),
+		Run:   runLicenseImport,
+	}
+	licenseExportCmd := &cobra.Command{
+		Use:   "export FILE",
+		Short: "Write the stored license blob to a file",
+		Args:  cobra.ExactArgs(1),
+		Run:   runLicenseExport,
+	}
+	licenseCmd.AddCommand(licenseActivateCmd, licenseStatusCmd, licenseVerifyCmd, licenseClearCmd, licenseImportCmd, licenseExportCmd)
+
+	// Telemetry command group
+	telemetryCmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Inspect locally-collected telemetry",
+	}
+	telemetryReportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize recorded sessions and errors",
+		Run:   runTelemetryReport,
+	}
+	telemetryReportCmd.Flags().String("since", "7d", "Period to report over, e.g. 24h, 7d, 30d")
+	telemetryReportCmd.Flags().String("format", "text", "Output format: text, json, or csv")
+	telemetryMaintainCmd := &cobra.Command{
+		Use:   "maintain",
+		Short: "Compress and prune event files per the retention policy",
+		Run:   runTelemetryMaintain,
+	}
+	telemetryCmd.AddCommand(telemetryReportCmd, telemetryMaintainCmd)
+
+	rootCmd.AddCommand(configCmd, setupCmd, doctorCmd, statusCmd, activateCmd, removeCmd, infoCmd, sysinfoCmd, logsCmd, dbusPolicyCmd, psCmd, reapCmd, hooksCmd, licenseCmd, telemetryCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -155,9 +321,16 @@ func runLaunch(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	
+	if sandboxFlag, _ := cmd.Flags().GetBool("sandbox"); sandboxFlag {
+		cfg.SandboxEnabled = true
+	}
+	if noHooks, _ := cmd.Flags().GetBool("no-hooks"); noHooks {
+		cfg.HooksEnabled = false
+	}
+
 	// Log system info
 	log.WithFields(map[string]any{
-		"gpu":         sysInfo.GPU.Primary,
+		"gpu":         gpuSummary(sysInfo.GPU),
 		"cpu":         sysInfo.CPU.Model,
 		"ram_gib":     sysInfo.Memory.TotalMiB / 1024,
 		"resolution":  fmt.Sprintf("%dx%d", sysInfo.Display.Primary.Width, sysInfo.Display.Primary.Height),
@@ -172,6 +345,15 @@ func runLaunch(cmd *cobra.Command, args []string) {
 	}
 }
 
+// gpuSummary renders the primary GPU's name for logging, or a placeholder
+// if sysinfo didn't find one.
+func gpuSummary(gpu sysinfo.GPUInfo) string {
+	if primary := gpu.Primary(); primary != nil && primary.Name != "" {
+		return primary.Name
+	}
+	return "unknown"
+}
+
 func runConfig(cmd *cobra.Command, args []string) {
 	if _, err := tui.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -182,18 +364,44 @@ func runConfig(cmd *cobra.Command, args []string) {
 func runSetup(cmd *cobra.Command, args []string) {
 	nonInteractive, _ := cmd.Flags().GetBool("yes")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
-	
+	uninstall, _ := cmd.Flags().GetBool("uninstall")
+	signModules, _ := cmd.Flags().GetBool("sign-modules")
+	keepPartial, _ := cmd.Flags().GetBool("keep-partial")
+
+	if cmd.Flags().Changed("rollback") {
+		id, _ := cmd.Flags().GetString("rollback")
+		if id == "latest" {
+			id = ""
+		}
+		if err := setup.RollbackSnapshot(id); err != nil {
+			fmt.Fprintf(os.Stderr, "Rollback failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	opts := setup.Options{
 		NonInteractive: nonInteractive,
 		DryRun:         dryRun,
+		SignModules:    signModules,
+		KeepPartial:    keepPartial,
 	}
-	
+
+	if uninstall {
+		if err := setup.Uninstall(opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Uninstall failed: %v\n", err)
+			telemetry.RecordError("setup", err.Error(), nil)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := setup.Run(opts); err != nil {
 		fmt.Fprintf(os.Stderr, "Setup failed: %v\n", err)
 		telemetry.RecordError("setup", err.Error(), nil)
 		os.Exit(1)
 	}
-	
+
 	telemetry.RecordEvent(telemetry.EventSetup, map[string]any{
 		"success": true,
 	})
@@ -217,17 +425,189 @@ func runStatus(cmd *cobra.Command, args []string) {
 		tooltip = "Wizado - Steam Gaming Mode\\n━━━━━━━━━━━━━━━━━━━━━━━\\n✗ License Required\\n$10 for 5 machines\\nwizado.app\\n\\nLeft-click: Launch Steam\\nRight-click: Menu"
 	}
 	
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+
 	output := map[string]string{
-		"text":    icon,
-		"tooltip": tooltip,
-		"class":   class,
-		"alt":     alt,
+		"text":        icon,
+		"tooltip":     tooltip,
+		"class":       class,
+		"alt":         alt,
+		"enablements": cfg.Enablements(),
 	}
 	
 	jsonData, _ := json.Marshal(output)
 	fmt.Println(string(jsonData))
 }
 
+func runPs(cmd *cobra.Command, args []string) {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	sessions, err := state.List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing sessions: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.Marshal(sessions)
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("No active sessions")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PID\tSTARTED\tALIVE\tSANDBOX\tENABLEMENTS\tLOG")
+	for _, s := range sessions {
+		sandboxUser := s.SandboxUser
+		if sandboxUser == "" {
+			sandboxUser = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%v\t%s\t%s\t%s\n",
+			s.PID, s.StartTime.Format("15:04:05"), state.IsAlive(s.PID), sandboxUser, s.Enablements, s.LogPath)
+	}
+	w.Flush()
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	results := validate.RunAll()
+
+	if jsonOutput {
+		data, _ := json.Marshal(results)
+		fmt.Println(string(data))
+	} else {
+		printDoctorTable(results)
+	}
+
+	for _, r := range results {
+		if !r.Pass {
+			os.Exit(1)
+		}
+	}
+}
+
+func printDoctorTable(results []validate.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROBE\tPASS\tDETAIL\tFIX")
+	for _, r := range results {
+		pass := "✓"
+		if !r.Pass {
+			pass = "✗"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, pass, r.Detail, r.Fix)
+	}
+	w.Flush()
+}
+
+func runReap(cmd *cobra.Command, args []string) {
+	stale, err := state.Reap()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reaping sessions: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(stale) == 0 {
+		fmt.Println("Nothing to reap")
+		return
+	}
+
+	for _, s := range stale {
+		fmt.Printf("Reaped stale session pid=%d (started %s)\n", s.PID, s.StartTime.Format(time.RFC3339))
+	}
+}
+
+func runHooksList(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+
+	fmt.Println("Configured hooks")
+	fmt.Println("════════════════")
+	printPolicyRules("pre_launch", cfg.Hooks.PreLaunch)
+	printPolicyRules("post_launch", cfg.Hooks.PostLaunch)
+	printPolicyRules("on_crash", cfg.Hooks.OnCrash)
+}
+
+func runHooksRun(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+
+	var errs []error
+	switch stage := args[0]; stage {
+	case "pre_launch":
+		errs = hooks.RunPreLaunch(cfg)
+	case "post_launch":
+		errs = hooks.RunPostLaunch(cfg)
+	case "on_crash":
+		errs = hooks.RunOnCrash(cfg)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown hook stage %q (want pre_launch, post_launch, or on_crash)\n", stage)
+		os.Exit(1)
+	}
+
+	if len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "Hook failed: %v\n", e)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("%s hooks completed\n", args[0])
+}
+
+func runHooksTest(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Default()
+	}
+
+	fmt.Println("Running pre_launch hooks...")
+	errs := hooks.RunPreLaunch(cfg)
+	fmt.Println("Running post_launch hooks...")
+	errs = append(errs, hooks.RunPostLaunch(cfg)...)
+	fmt.Println("Running on_crash hooks...")
+	errs = append(errs, hooks.RunOnCrash(cfg)...)
+
+	if len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "%d hook(s) failed\n", len(errs))
+		os.Exit(1)
+	}
+	fmt.Println("All hooks ran successfully")
+}
+
+func runDBusPolicy(cmd *cobra.Command, args []string) {
+	policy := dbus.DefaultPolicy()
+
+	fmt.Println("Effective xdg-dbus-proxy session bus policy")
+	fmt.Println("════════════════════════════════════════")
+	printPolicyRules("own", policy.Own)
+	printPolicyRules("talk", policy.Talk)
+	printPolicyRules("see", policy.See)
+	printPolicyRules("broadcast", policy.Broadcast)
+	printPolicyRules("call", policy.Call)
+	fmt.Println("\nEverything else is denied by default.")
+}
+
+func printPolicyRules(kind string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("  %s:\n", kind)
+	for _, name := range names {
+		fmt.Printf("    - %s\n", name)
+	}
+}
+
 func runActivate(cmd *cobra.Command, args []string) {
 	email := args[0]
 	key := args[1]
@@ -262,7 +642,20 @@ func runRemove(cmd *cobra.Command, args []string) {
 	}
 	
 	home, _ := os.UserHomeDir()
-	
+
+	// Finish cleanup for any sessions a crash left behind: revoke their
+	// ACLs, kill their D-Bus proxy, and reap the sandbox user as a fallback
+	if stale, err := state.Reap(); err == nil && len(stale) > 0 {
+		fmt.Printf("Reaped %d stale session(s)\n", len(stale))
+	}
+	sandboxUser := sandbox.DefaultUser
+	if cfg, err := config.Load(); err == nil && cfg.SandboxUser != "" {
+		sandboxUser = cfg.SandboxUser
+	}
+	if err := sandbox.ReapProcesses(sandboxUser); err == nil {
+		fmt.Printf("Reaped leftover sandbox processes for %s\n", sandboxUser)
+	}
+
 	// Remove config directory
 	configDir := filepath.Join(home, ".config", "wizado")
 	if err := os.RemoveAll(configDir); err != nil {
@@ -370,6 +763,258 @@ func runInfo(cmd *cobra.Command, args []string) {
 	// Telemetry status
 	stats, _ := telemetry.Default().GetStats()
 	fmt.Printf("  Telemetry: %v events recorded\n", stats["event_count"])
+
+	// Hooks status
+	if cfg, err := config.Load(); err == nil {
+		hookCount := len(cfg.Hooks.PreLaunch) + len(cfg.Hooks.PostLaunch) + len(cfg.Hooks.OnCrash)
+		if cfg.HooksEnabled {
+			fmt.Printf("  Hooks: %d configured\n", hookCount)
+		} else {
+			fmt.Printf("  Hooks: disabled in config, %d configured\n", hookCount)
+		}
+	}
+}
+
+// runSysinfo collects system diagnostics and renders them straight to
+// stdout in the requested machine-readable format, skipping the TUI and
+// the human-facing framing runInfo adds.
+func runSysinfo(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+
+	info := sysinfo.Collect(Version)
+	if err := sysinfo.Render(info, format, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runSysinfoSnapshot captures a redacted support bundle into args[0], for
+// support tickets or before/after comparisons with `sysinfo diff`.
+func runSysinfoSnapshot(cmd *cobra.Command, args []string) {
+	info := sysinfo.Collect(Version)
+	if err := sysinfo.Snapshot(info, args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Snapshot written to %s\n", args[0])
+}
+
+// runSysinfoDiff compares two snapshot directories and prints a structured
+// diff as JSON, for regression reports.
+func runSysinfoDiff(cmd *cobra.Command, args []string) {
+	diff, err := sysinfo.DiffSnapshots(args[0], args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	data, _ := json.MarshalIndent(diff, "", "  ")
+	fmt.Println(string(data))
+}
+
+// runSysinfoVerify checks args[0]'s manifest.sig against its current
+// contents, catching a snapshot bundle that was edited or had a file
+// added/removed after it was signed.
+func runSysinfoVerify(cmd *cobra.Command, args []string) {
+	ok, err := sysinfo.VerifySnapshot(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !ok {
+		fmt.Println("Snapshot signature does not match - bundle may have been modified")
+		os.Exit(1)
+	}
+	fmt.Println("Snapshot signature OK")
+}
+
+// licenseStatusJSON is the `license status --json` / `license verify --json`
+// payload - a flattened view of license.Result that marshals cleanly (a raw
+// license.Result's Error field is an interface and won't serialize usefully).
+type licenseStatusJSON struct {
+	Status       license.Status  `json:"status"`
+	Error        string          `json:"error,omitempty"`
+	Email        string          `json:"email,omitempty"`
+	Tier         string          `json:"tier,omitempty"`
+	Features     map[string]bool `json:"features,omitempty"`
+	ExpiresAt    *time.Time      `json:"expiresAt,omitempty"`
+	LastVerified *time.Time      `json:"lastVerified,omitempty"`
+	SeatsUsed    int             `json:"seatsUsed,omitempty"`
+	SeatsTotal   int             `json:"seatsTotal,omitempty"`
+}
+
+// licenseExitCode maps a license.Status to the exit code scripts branch on,
+// per the `wizado license` CLI's documented contract.
+func licenseExitCode(status license.Status) int {
+	switch status {
+	case license.StatusValid, license.StatusOfflineGrace:
+		return 0
+	case license.StatusNoLicense:
+		return 2
+	case license.StatusExpired, license.StatusOfflineExpired:
+		return 3
+	case license.StatusMachineMismatch:
+		return 4
+	case license.StatusTampered:
+		return 5
+	default:
+		return 1
+	}
+}
+
+// printLicenseStatus renders a license.Result either as the same
+// human-readable summary the TUI's status screen shows, or as JSON.
+func printLicenseStatus(result license.Result, jsonOutput bool) {
+	if jsonOutput {
+		out := licenseStatusJSON{Status: result.Status}
+		if result.Error != nil {
+			out.Error = result.Error.Error()
+		}
+		if result.License != nil {
+			out.Email = result.License.Email
+			out.Tier = result.License.Tier
+			out.Features = result.License.Features
+			out.SeatsUsed = result.License.SeatsUsed
+			out.SeatsTotal = result.License.SeatsTotal
+			if !result.License.ExpiresAt.IsZero() {
+				out.ExpiresAt = &result.License.ExpiresAt
+			}
+			if !result.License.LastVerified.IsZero() {
+				out.LastVerified = &result.License.LastVerified
+			}
+		}
+		data, _ := json.Marshal(out)
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Status: %s\n", result.Status)
+	if result.License != nil {
+		fmt.Printf("Email: %s\n", result.License.Email)
+		if result.License.Tier != "" {
+			fmt.Printf("Tier: %s\n", result.License.Tier)
+		}
+		if !result.License.ExpiresAt.IsZero() {
+			fmt.Printf("Expires: %s\n", result.License.ExpiresAt.Format("2006-01-02"))
+		}
+		if !result.License.LastVerified.IsZero() {
+			fmt.Printf("Last Verified: %s\n", result.License.LastVerified.Format("2006-01-02 15:04"))
+		}
+	}
+	if result.Error != nil {
+		fmt.Printf("Error: %v\n", result.Error)
+	}
+}
+
+func runLicenseActivate(cmd *cobra.Command, args []string) {
+	email, _ := cmd.Flags().GetString("email")
+	key, _ := cmd.Flags().GetString("key")
+
+	result, err := license.Activate(email, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Activation failed: %v\n", err)
+		os.Exit(1)
+	}
+	if !result.Success {
+		fmt.Fprintf(os.Stderr, "Activation failed: %s\n", result.Message)
+		os.Exit(1)
+	}
+
+	fmt.Printf("License activated successfully!\n")
+	fmt.Printf("Email: %s\n", result.Email)
+	fmt.Printf("Slots: %d/%d\n", result.SlotsUsed, result.SlotsTotal)
+}
+
+func runLicenseStatus(cmd *cobra.Command, args []string) {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	result := license.Check()
+	printLicenseStatus(result, jsonOutput)
+	os.Exit(licenseExitCode(result.Status))
+}
+
+func runLicenseVerify(cmd *cobra.Command, args []string) {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	result := license.Verify()
+	printLicenseStatus(result, jsonOutput)
+	os.Exit(licenseExitCode(result.Status))
+}
+
+func runLicenseClear(cmd *cobra.Command, args []string) {
+	if err := license.Clear(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error clearing license: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("License cleared")
+}
+
+func runLicenseImport(cmd *cobra.Command, args []string) {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+
+	if _, err := license.ValidateBlob(string(data)); err != nil {
+		fmt.Fprintf(os.Stderr, "Refusing to import: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := license.Save(string(data)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error installing license: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("License imported from %s\n", args[0])
+}
+
+func runLicenseExport(cmd *cobra.Command, args []string) {
+	current, err := license.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading license: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(args[0], []byte(current.Raw), 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+	fmt.Printf("License exported to %s\n", args[0])
+}
+
+func runTelemetryReport(cmd *cobra.Command, args []string) {
+	since, _ := cmd.Flags().GetString("since")
+	format, _ := cmd.Flags().GetString("format")
+
+	period, err := telemetry.ParsePeriod(since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := telemetry.Default().GenerateReport(period)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch format {
+	case "json":
+		data, _ := report.ToJSON()
+		fmt.Println(string(data))
+	case "csv":
+		fmt.Print(report.ToCSV())
+	case "text":
+		fmt.Print(report.Summary())
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown --format %q (want text, json, or csv)\n", format)
+		os.Exit(1)
+	}
+}
+
+func runTelemetryMaintain(cmd *cobra.Command, args []string) {
+	if err := telemetry.Default().Maintain(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error maintaining telemetry store: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Telemetry store maintained")
 }
 
 func runLogs(cmd *cobra.Command, args []string) {
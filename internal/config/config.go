@@ -3,15 +3,26 @@ package config
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+// currentConfigVersion is the schema version Save writes, and the version
+// Load/Migrate bring every on-disk config up to.
+const currentConfigVersion = 1
+
 // Config holds wizado settings
 type Config struct {
+	// Version is the on-disk schema version. 0 denotes the legacy flat
+	// WIZADO_KEY=value format, which had no version line at all; Load
+	// detects it and runs it through Migrate.
+	Version int
+
 	Resolution string // "auto" or "WIDTHxHEIGHT"
 	FSR        string // "off", "ultra", "quality", "balanced", "performance"
 	FrameLimit int    // 0 = unlimited
@@ -19,18 +30,162 @@ type Config struct {
 	MangoHUD   bool   // Performance overlay
 	SteamUI    string // "gamepadui" or "tenfoot"
 	Workspace  int    // Hyprland workspace number
+
+	// Scaling/color management, passed through to gamescope
+	Scaler      string  // "fsr", "nis", "integer", "nearest", "linear", "auto"
+	Sharpness   int     // 0-20, used by both FSR and NIS sharpening
+	HDR         bool    // Enable HDR output
+	HDRPeakNits int     // SDR content nits when HDR is enabled
+	HDRITM      bool    // Inverse tone mapping for SDR content under HDR
+	MaxScale    float64 // Maximum scale factor, 0 = gamescope default
+
+	// MangoHUDSettings controls the generated per-session MangoHUD config, used
+	// only when MangoHUD above is enabled
+	MangoHUDSettings MangoHUD
+
+	// StreamingMode controls Remote Play / Steam Link handling: "off", "host"
+	// (tune for streaming from first launch), or "auto" (detect and adapt)
+	StreamingMode string
+
+	// Output selects which monitor gamescope binds to: a monitor name like
+	// "DP-1", or "auto"/"primary" (first reported monitor) / "largest"
+	Output string
+	// RefreshRate overrides the output's refresh rate passed to gamescope, 0 = use the monitor's own
+	RefreshRate int
+	// RefreshRates drives STEAM_DISPLAY_REFRESH_LIMITS
+	RefreshRates []int
+
+	// SandboxEnabled runs Steam/gamescope as SandboxUser instead of the
+	// desktop user, with access to Wayland/audio sockets granted via ACLs
+	SandboxEnabled bool
+	// SandboxUser is the unprivileged account the session runs as, empty = "wizado-play"
+	SandboxUser string
+
+	// Enablements gate which resources the session's environment exposes.
+	// Disabling one removes the corresponding env var/namespace instead of
+	// just leaving it unused, shrinking what a compromised game can reach.
+	EnableWayland bool // WAYLAND_DISPLAY
+	EnableX11     bool // DISPLAY, for games that still need XWayland
+	EnablePulse   bool // PULSE_SERVER
+	EnableDBus    bool // DBUS_SESSION_BUS_ADDRESS
+	EnableNetwork bool // off runs the session inside `unshare -n`
+	EnablePortal  bool // xdg-desktop-portal access (file chooser, notifications, ...)
+
+	// DBusProxyEnabled routes the session through an xdg-dbus-proxy filtering
+	// the real session bus, instead of exposing it directly. Only takes
+	// effect when EnableDBus is also set.
+	DBusProxyEnabled bool
+
+	// HooksEnabled gates running Hooks below, disabled with --no-hooks
+	HooksEnabled bool
+	// Hooks are the shell commands run around the session
+	Hooks Hooks
+
+	// Games holds per-Steam-AppID overrides, keyed by AppID (e.g. "440").
+	// The launcher consults these before spawning gamescope so a handful
+	// of troublesome titles can pin a different FSR mode or frame limit
+	// without changing the global default.
+	Games map[string]GameOverride
+
+	// extra holds whatever sections/keys Load found in the config file
+	// that configFromTOML didn't recognize - a newer wizado binary's
+	// addition, most likely. Save writes it straight back out so editing
+	// an older field doesn't silently drop the rest of the file. Nil for
+	// a Config built with Default() or constructed in code.
+	extra *tomlDoc
+}
+
+// GameOverride holds the per-title values a [games.<appid>] section may
+// set. Pointer fields distinguish "not set" (nil, fall back to the global
+// Config) from an explicit override, including an explicit zero value.
+type GameOverride struct {
+	FSR        *string
+	FrameLimit *int
+}
+
+// Hooks holds the ordered shell commands run around a gaming session.
+// Commands are stored comma-separated on disk, so a command containing a
+// comma isn't supported by this format.
+type Hooks struct {
+	PreLaunch  []string // run in order before Steam starts
+	PostLaunch []string // run in order after the session ends, success or not
+	OnCrash    []string // run in order, in addition to PostLaunch, only on a non-zero exit
+}
+
+// MangoHUD controls the contents of the per-session MangoHUD overlay/logging config
+type MangoHUD struct {
+	Position       string // "top-left", "top-right", "bottom-left", "bottom-right"
+	FontSize       int
+	ShowFPSLimit   bool
+	ShowGPU        bool
+	ShowCPU        bool
+	ShowRAM        bool
+	ShowVRAM       bool
+	ShowTemp       bool
+	ShowPower      bool
+	FrametimeGraph bool
+	LogInterval    int  // ms between CSV log samples, 0 = MangoHud default
+	NoDisplay      bool // suppress the overlay entirely, useful for headless benchmark runs
+}
+
+// DefaultMangoHUD returns the default MangoHUD overlay configuration
+func DefaultMangoHUD() MangoHUD {
+	return MangoHUD{
+		Position:       "top-left",
+		FontSize:       24,
+		ShowFPSLimit:   true,
+		ShowGPU:        true,
+		ShowCPU:        true,
+		ShowRAM:        true,
+		ShowVRAM:       true,
+		ShowTemp:       true,
+		ShowPower:      true,
+		FrametimeGraph: true,
+		LogInterval:    0,
+		NoDisplay:      false,
+	}
 }
 
 // Default returns the default configuration
 func Default() *Config {
 	return &Config{
-		Resolution: "auto",
-		FSR:        "off",
-		FrameLimit: 0,
-		VRR:        false,
-		MangoHUD:   false,
-		SteamUI:    "tenfoot",
-		Workspace:  10,
+		Version:     currentConfigVersion,
+		Resolution:  "auto",
+		FSR:         "off",
+		FrameLimit:  0,
+		VRR:         false,
+		MangoHUD:    false,
+		SteamUI:     "tenfoot",
+		Workspace:   10,
+		Scaler:      "auto",
+		Sharpness:   5,
+		HDR:         false,
+		HDRPeakNits: 0,
+		HDRITM:      false,
+		MaxScale:    0,
+
+		MangoHUDSettings: DefaultMangoHUD(),
+		StreamingMode:    "off",
+
+		Output:       "auto",
+		RefreshRate:  0,
+		RefreshRates: []int{60, 72, 120, 144},
+
+		SandboxEnabled: false,
+		SandboxUser:    "wizado-play",
+
+		EnableWayland: true,
+		EnableX11:     true,
+		EnablePulse:   true,
+		EnableDBus:    true,
+		EnableNetwork: true,
+		EnablePortal:  true,
+
+		DBusProxyEnabled: false,
+
+		HooksEnabled: true,
+
+		Games: map[string]GameOverride{},
 	}
 }
 
@@ -42,96 +197,348 @@ func Paths() (dir string, file string) {
 	return
 }
 
-// Load reads the configuration from disk
+// Load reads the configuration from disk, auto-detecting the legacy flat
+// WIZADO_KEY=value format versus the current TOML format and migrating the
+// former forward via Migrate.
 func Load() (*Config, error) {
-	cfg := Default()
 	_, configFile := Paths()
-	
-	file, err := os.Open(configFile)
+	return loadFile(configFile)
+}
+
+// loadFile reads and parses the config at path, returning Default() if it
+// doesn't exist yet.
+func loadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return cfg, nil // Return defaults if no config
+			return Default(), nil
 		}
 		return nil, err
 	}
+	return parseConfig(data)
+}
+
+// legacyFormat reports whether data is the pre-v1 flat WIZADO_KEY=value
+// config rather than the current TOML format: the legacy format's keys are
+// always upper-cased and prefixed with "WIZADO_", which a TOML key or
+// section line never starts with.
+func legacyFormat(data []byte) bool {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.HasPrefix(line, "WIZADO_")
+	}
+	return false
+}
+
+// parseConfig builds a Config from raw file contents in either format,
+// migrating a legacy file forward to the current schema version.
+func parseConfig(data []byte) (*Config, error) {
+	if legacyFormat(data) {
+		cfg := Default()
+		cfg.Version = 0
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			applyKV(cfg, strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		return Migrate(cfg, 0)
+	}
+
+	doc, err := parseTOML(data)
+	if err != nil {
+		return nil, err
+	}
+	return configFromTOML(doc), nil
+}
+
+// Migrate upgrades old, loaded under fromVersion, to the current schema.
+// fromVersion 0 is the legacy flat format, which had no concept of Version
+// or per-game overrides - every other field it understood already has a
+// matching Config field, so there's nothing else to carry over.
+func Migrate(old *Config, fromVersion int) (*Config, error) {
+	switch fromVersion {
+	case currentConfigVersion:
+		return old, nil
+	case 0:
+		migrated := *old
+		migrated.Version = currentConfigVersion
+		if migrated.Games == nil {
+			migrated.Games = map[string]GameOverride{}
+		}
+		return &migrated, nil
+	default:
+		return nil, fmt.Errorf("config: don't know how to migrate from version %d", fromVersion)
+	}
+}
+
+// dropInDir is where LoadMerged looks for additional *.conf fragments,
+// applied after the base config in lexical order.
+func dropInDir() string {
+	dir, _ := Paths()
+	return filepath.Join(dir, "conf.d")
+}
+
+// LoadMerged reads the base config, applies every conf.d/*.conf drop-in in
+// lexical order, and finally applies any WIZADO_* environment variables
+// that are set - base config overridden by drop-ins overridden by env vars.
+// This is what Watcher uses to rebuild its snapshot on every reload.
+// Drop-ins stay in the legacy WIZADO_KEY=value form regardless of the base
+// config's format: they're small, targeted overrides, not full configs.
+func LoadMerged() (*Config, error) {
+	_, configFile := Paths()
+	cfg, err := loadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	dropins, err := filepath.Glob(filepath.Join(dropInDir(), "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dropins)
+	for _, path := range dropins {
+		if err := applyFile(cfg, path); err != nil {
+			return nil, err
+		}
+	}
+
+	applyEnv(cfg)
+
+	return cfg, nil
+}
+
+// applyFile applies every WIZADO_KEY=value line in the file at path to cfg.
+// A missing file is not an error - both the base config and any given
+// drop-in are optional.
+func applyFile(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
 	defer file.Close()
-	
+
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		
+
 		parts := strings.SplitN(line, "=", 2)
 		if len(parts) != 2 {
 			continue
 		}
-		
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		
-		switch key {
-		case "WIZADO_RESOLUTION":
-			cfg.Resolution = value
-		case "WIZADO_FSR":
-			cfg.FSR = value
-		case "WIZADO_FRAMELIMIT":
-			if v, err := strconv.Atoi(value); err == nil {
-				cfg.FrameLimit = v
-			}
-		case "WIZADO_VRR":
-			cfg.VRR = value == "on"
-		case "WIZADO_MANGOHUD":
-			cfg.MangoHUD = value == "on"
-		case "WIZADO_STEAM_UI":
-			cfg.SteamUI = value
-		case "WIZADO_WORKSPACE":
-			if v, err := strconv.Atoi(value); err == nil {
-				cfg.Workspace = v
+
+		applyKV(cfg, strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+
+	return scanner.Err()
+}
+
+// applyEnv applies any of the WIZADO_* environment variables that are set,
+// taking precedence over both the base config and its drop-ins.
+func applyEnv(cfg *Config) {
+	for _, key := range envKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			applyKV(cfg, key, value)
+		}
+	}
+}
+
+// envKeys lists every WIZADO_KEY applyKV understands, so applyEnv knows
+// which environment variables to check.
+var envKeys = []string{
+	"WIZADO_RESOLUTION", "WIZADO_FSR", "WIZADO_FRAMELIMIT", "WIZADO_VRR",
+	"WIZADO_MANGOHUD", "WIZADO_STEAM_UI", "WIZADO_WORKSPACE", "WIZADO_SCALER",
+	"WIZADO_SHARPNESS", "WIZADO_HDR", "WIZADO_HDR_PEAK_NITS", "WIZADO_HDR_ITM",
+	"WIZADO_MAX_SCALE", "WIZADO_MANGOHUD_POSITION", "WIZADO_MANGOHUD_FONT_SIZE",
+	"WIZADO_MANGOHUD_SHOW_FPS_LIMIT", "WIZADO_MANGOHUD_SHOW_GPU", "WIZADO_MANGOHUD_SHOW_CPU",
+	"WIZADO_MANGOHUD_SHOW_RAM", "WIZADO_MANGOHUD_SHOW_VRAM", "WIZADO_MANGOHUD_SHOW_TEMP",
+	"WIZADO_MANGOHUD_SHOW_POWER", "WIZADO_MANGOHUD_FRAMETIME_GRAPH", "WIZADO_MANGOHUD_LOG_INTERVAL",
+	"WIZADO_MANGOHUD_NO_DISPLAY", "WIZADO_STREAMING_MODE", "WIZADO_OUTPUT",
+	"WIZADO_REFRESH_RATE", "WIZADO_REFRESH_RATES", "WIZADO_SANDBOX_ENABLED",
+	"WIZADO_SANDBOX_USER", "WIZADO_ENABLE_WAYLAND", "WIZADO_ENABLE_X11",
+	"WIZADO_ENABLE_PULSE", "WIZADO_ENABLE_DBUS", "WIZADO_ENABLE_NETWORK",
+	"WIZADO_ENABLE_PORTAL", "WIZADO_DBUS_PROXY_ENABLED", "WIZADO_HOOKS_ENABLED",
+	"WIZADO_HOOKS_PRE_LAUNCH", "WIZADO_HOOKS_POST_LAUNCH", "WIZADO_HOOKS_ON_CRASH",
+}
+
+// applyKV applies a single WIZADO_KEY=value pair to cfg, used for both
+// config-file lines and environment variable overrides.
+func applyKV(cfg *Config, key, value string) {
+	switch key {
+	case "WIZADO_RESOLUTION":
+		cfg.Resolution = value
+	case "WIZADO_FSR":
+		cfg.FSR = value
+	case "WIZADO_FRAMELIMIT":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.FrameLimit = v
+		}
+	case "WIZADO_VRR":
+		cfg.VRR = value == "on"
+	case "WIZADO_MANGOHUD":
+		cfg.MangoHUD = value == "on"
+	case "WIZADO_STEAM_UI":
+		cfg.SteamUI = value
+	case "WIZADO_WORKSPACE":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.Workspace = v
+		}
+	case "WIZADO_SCALER":
+		cfg.Scaler = value
+	case "WIZADO_SHARPNESS":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.Sharpness = v
+		}
+	case "WIZADO_HDR":
+		cfg.HDR = value == "on"
+	case "WIZADO_HDR_PEAK_NITS":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.HDRPeakNits = v
+		}
+	case "WIZADO_HDR_ITM":
+		cfg.HDRITM = value == "on"
+	case "WIZADO_MAX_SCALE":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			cfg.MaxScale = v
+		}
+	case "WIZADO_MANGOHUD_POSITION":
+		cfg.MangoHUDSettings.Position = value
+	case "WIZADO_MANGOHUD_FONT_SIZE":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.MangoHUDSettings.FontSize = v
+		}
+	case "WIZADO_MANGOHUD_SHOW_FPS_LIMIT":
+		cfg.MangoHUDSettings.ShowFPSLimit = value == "on"
+	case "WIZADO_MANGOHUD_SHOW_GPU":
+		cfg.MangoHUDSettings.ShowGPU = value == "on"
+	case "WIZADO_MANGOHUD_SHOW_CPU":
+		cfg.MangoHUDSettings.ShowCPU = value == "on"
+	case "WIZADO_MANGOHUD_SHOW_RAM":
+		cfg.MangoHUDSettings.ShowRAM = value == "on"
+	case "WIZADO_MANGOHUD_SHOW_VRAM":
+		cfg.MangoHUDSettings.ShowVRAM = value == "on"
+	case "WIZADO_MANGOHUD_SHOW_TEMP":
+		cfg.MangoHUDSettings.ShowTemp = value == "on"
+	case "WIZADO_MANGOHUD_SHOW_POWER":
+		cfg.MangoHUDSettings.ShowPower = value == "on"
+	case "WIZADO_MANGOHUD_FRAMETIME_GRAPH":
+		cfg.MangoHUDSettings.FrametimeGraph = value == "on"
+	case "WIZADO_MANGOHUD_LOG_INTERVAL":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.MangoHUDSettings.LogInterval = v
+		}
+	case "WIZADO_MANGOHUD_NO_DISPLAY":
+		cfg.MangoHUDSettings.NoDisplay = value == "on"
+	case "WIZADO_STREAMING_MODE":
+		cfg.StreamingMode = value
+	case "WIZADO_OUTPUT":
+		cfg.Output = value
+	case "WIZADO_REFRESH_RATE":
+		if v, err := strconv.Atoi(value); err == nil {
+			cfg.RefreshRate = v
+		}
+	case "WIZADO_REFRESH_RATES":
+		cfg.RefreshRates = nil
+		for _, part := range strings.Split(value, ",") {
+			if v, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+				cfg.RefreshRates = append(cfg.RefreshRates, v)
 			}
 		}
+	case "WIZADO_SANDBOX_ENABLED":
+		cfg.SandboxEnabled = value == "on"
+	case "WIZADO_SANDBOX_USER":
+		cfg.SandboxUser = value
+	case "WIZADO_ENABLE_WAYLAND":
+		cfg.EnableWayland = value == "on"
+	case "WIZADO_ENABLE_X11":
+		cfg.EnableX11 = value == "on"
+	case "WIZADO_ENABLE_PULSE":
+		cfg.EnablePulse = value == "on"
+	case "WIZADO_ENABLE_DBUS":
+		cfg.EnableDBus = value == "on"
+	case "WIZADO_ENABLE_NETWORK":
+		cfg.EnableNetwork = value == "on"
+	case "WIZADO_ENABLE_PORTAL":
+		cfg.EnablePortal = value == "on"
+	case "WIZADO_DBUS_PROXY_ENABLED":
+		cfg.DBusProxyEnabled = value == "on"
+	case "WIZADO_HOOKS_ENABLED":
+		cfg.HooksEnabled = value == "on"
+	case "WIZADO_HOOKS_PRE_LAUNCH":
+		cfg.Hooks.PreLaunch = splitHookList(value)
+	case "WIZADO_HOOKS_POST_LAUNCH":
+		cfg.Hooks.PostLaunch = splitHookList(value)
+	case "WIZADO_HOOKS_ON_CRASH":
+		cfg.Hooks.OnCrash = splitHookList(value)
+	}
+}
+
+// Validate rejects a Config with settings that would reach subscribers (or
+// disk) in a state the rest of wizado can't handle: an FSR mode outside
+// FSROptions, or a workspace number outside Hyprland's 1-10 range.
+func (cfg *Config) Validate() error {
+	validFSR := false
+	for _, opt := range FSROptions() {
+		if cfg.FSR == opt {
+			validFSR = true
+			break
+		}
+	}
+	if !validFSR {
+		return fmt.Errorf("invalid FSR mode %q (want one of %s)", cfg.FSR, strings.Join(FSROptions(), ", "))
+	}
+
+	if cfg.Workspace < 1 || cfg.Workspace > 10 {
+		return fmt.Errorf("workspace %d out of range (1-10)", cfg.Workspace)
+	}
+
+	return nil
+}
+
+// splitHookList parses a comma-separated WIZADO_HOOKS_* value, dropping
+// empty entries.
+func splitHookList(value string) []string {
+	var commands []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			commands = append(commands, part)
+		}
 	}
-	
-	return cfg, scanner.Err()
+	return commands
 }
 
-// Save writes the configuration to disk
+// Save writes the configuration to disk in the current TOML format. A
+// Config loaded from a TOML file keeps whatever Load couldn't parse on its
+// extra field, so re-saving it doesn't drop that content. Unknown keys in
+// a legacy WIZADO_KEY=value file are not kept and are dropped on save.
 func Save(cfg *Config) error {
 	dir, configFile := Paths()
-	
+
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
-	
-	vrr := "off"
-	if cfg.VRR {
-		vrr = "on"
-	}
-	
-	mangohud := "off"
-	if cfg.MangoHUD {
-		mangohud = "on"
-	}
-	
-	content := fmt.Sprintf(`WIZADO_RESOLUTION=%s
-WIZADO_FSR=%s
-WIZADO_FRAMELIMIT=%d
-WIZADO_VRR=%s
-WIZADO_MANGOHUD=%s
-WIZADO_STEAM_UI=%s
-WIZADO_WORKSPACE=%d
-`,
-		cfg.Resolution,
-		cfg.FSR,
-		cfg.FrameLimit,
-		vrr,
-		mangohud,
-		cfg.SteamUI,
-		cfg.Workspace,
-	)
-	
-	return os.WriteFile(configFile, []byte(content), 0644)
+
+	return os.WriteFile(configFile, tomlFromConfig(cfg), 0644)
 }
 
 // FSRScales returns the scaling factor for each FSR mode
@@ -164,3 +571,41 @@ func WorkspaceOptions() []int {
 	return []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 }
 
+// ScalerOptions returns available gamescope scaler backends
+func ScalerOptions() []string {
+	return []string{"auto", "fsr", "nis", "integer", "nearest", "linear"}
+}
+
+// StreamingModeOptions returns available Remote Play / Steam Link handling modes
+func StreamingModeOptions() []string {
+	return []string{"off", "host", "auto"}
+}
+
+// Enablements renders the resource bitfield as a short letter code, one
+// character per resource in Wayland/X11/Pulse/DBus/Network/Portal order,
+// "-" where disabled - used by waybar and the session registry to show the
+// isolation level at a glance.
+func (cfg *Config) Enablements() string {
+	bits := []struct {
+		enabled bool
+		letter  string
+	}{
+		{cfg.EnableWayland, "W"},
+		{cfg.EnableX11, "X"},
+		{cfg.EnablePulse, "P"},
+		{cfg.EnableDBus, "D"},
+		{cfg.EnableNetwork, "N"},
+		{cfg.EnablePortal, "O"},
+	}
+
+	var sb strings.Builder
+	for _, b := range bits {
+		if b.enabled {
+			sb.WriteString(b.letter)
+		} else {
+			sb.WriteString("-")
+		}
+	}
+	return sb.String()
+}
+
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfigRoundTrip(t *testing.T) {
+	original := Default()
+	original.FSR = "quality"
+	original.FrameLimit = 120
+	original.Games["440"] = GameOverride{FSR: strPtr("performance")}
+
+	reparsed, err := parseConfig(tomlFromConfig(original))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+
+	if reparsed.FSR != "quality" {
+		t.Errorf("FSR = %q, want %q", reparsed.FSR, "quality")
+	}
+	if reparsed.FrameLimit != 120 {
+		t.Errorf("FrameLimit = %d, want 120", reparsed.FrameLimit)
+	}
+	override, ok := reparsed.Games["440"]
+	if !ok || override.FSR == nil || *override.FSR != "performance" {
+		t.Errorf("Games[\"440\"] = %+v, want FSR=performance", override)
+	}
+}
+
+func TestParseConfigPreservesUnknownFields(t *testing.T) {
+	data := []byte(`version = 1
+
+[graphics]
+resolution = "auto"
+fsr = "off"
+frame_limit = 0
+vrr = false
+scaler = "auto"
+sharpness = 5
+hdr = false
+hdr_peak_nits = 0
+hdr_itm = false
+max_scale = 0
+output = "auto"
+refresh_rate = 0
+refresh_rates = [60, 72, 120, 144]
+upscale_filter = "nearest"
+
+[experimental]
+ray_tracing = "on"
+`)
+
+	cfg, err := parseConfig(data)
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+
+	out := string(tomlFromConfig(cfg))
+	if !strings.Contains(out, `upscale_filter = "nearest"`) {
+		t.Errorf("re-encoded config dropped unknown key in [graphics]:\n%s", out)
+	}
+	if !strings.Contains(out, "[experimental]") || !strings.Contains(out, `ray_tracing = "on"`) {
+		t.Errorf("re-encoded config dropped unknown [experimental] section:\n%s", out)
+	}
+}
+
+func TestMigrateLegacyFormat(t *testing.T) {
+	data := []byte(`WIZADO_RESOLUTION=auto
+WIZADO_FSR=quality
+WIZADO_FRAMELIMIT=60
+WIZADO_VRR=on
+`)
+
+	cfg, err := parseConfig(data)
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+
+	if cfg.Version != currentConfigVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, currentConfigVersion)
+	}
+	if cfg.Games == nil {
+		t.Error("Games = nil, want non-nil empty map")
+	}
+	if cfg.FSR != "quality" {
+		t.Errorf("FSR = %q, want %q", cfg.FSR, "quality")
+	}
+	if !cfg.VRR {
+		t.Error("VRR = false, want true")
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// profilesDir is where per-game launch profiles are stored, one
+// WIZADO_KEY=value file per Steam AppID, edited from the TUI's game
+// library screen.
+func profilesDir() string {
+	dir, _ := Paths()
+	return filepath.Join(dir, "profiles")
+}
+
+// ProfilePath returns the path a given AppID's profile is stored at,
+// whether or not it exists yet.
+func ProfilePath(appID string) string {
+	return filepath.Join(profilesDir(), appID+".conf")
+}
+
+// LoadProfile returns a copy of base with appID's profile (if any) layered
+// on top, in the same WIZADO_KEY=value form used by conf.d drop-ins - a
+// missing profile is not an error, and just returns base unchanged.
+func LoadProfile(base *Config, appID string) (*Config, error) {
+	merged := *base
+	if err := applyFile(&merged, ProfilePath(appID)); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// SaveProfile writes appID's resolution, FSR, frame limit, VRR, and
+// MangoHUD settings from cfg to its profile file, creating the profiles
+// directory if needed. Other Config fields aren't part of a profile - a
+// game keeps using the global config for everything else.
+func SaveProfile(appID string, cfg *Config) error {
+	if err := os.MkdirAll(profilesDir(), 0755); err != nil {
+		return err
+	}
+
+	lines := fmt.Sprintf(
+		"WIZADO_RESOLUTION=%s\nWIZADO_FSR=%s\nWIZADO_FRAMELIMIT=%d\nWIZADO_VRR=%s\nWIZADO_MANGOHUD=%s\n",
+		cfg.Resolution, cfg.FSR, cfg.FrameLimit, onOff(cfg.VRR), onOff(cfg.MangoHUD),
+	)
+	return os.WriteFile(ProfilePath(appID), []byte(lines), 0644)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
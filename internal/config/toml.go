@@ -0,0 +1,478 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tomlDoc is a parsed TOML-subset document: a "" section for any keys that
+// come before the first header, plus one tomlSection per [header], in file
+// order. configFromTOML consumes every key it recognizes via
+// tomlSection.take, so whatever's left in a tomlDoc afterwards is exactly
+// the content Save needs to write back out verbatim to avoid losing it.
+type tomlDoc struct {
+	order    []string
+	sections map[string]*tomlSection
+}
+
+// tomlSection holds one [header]'s key = value lines, in file order, with
+// values kept as unparsed text until a tomlXxx helper converts them.
+type tomlSection struct {
+	order  []string
+	values map[string]string
+}
+
+func newTomlDoc() *tomlDoc {
+	return &tomlDoc{sections: map[string]*tomlSection{}}
+}
+
+// section returns the named section, creating it (and recording it in
+// order) if this is the first reference to it.
+func (d *tomlDoc) section(name string) *tomlSection {
+	s, ok := d.sections[name]
+	if !ok {
+		s = &tomlSection{values: map[string]string{}}
+		d.sections[name] = s
+		d.order = append(d.order, name)
+	}
+	return s
+}
+
+func (s *tomlSection) set(key, value string) {
+	if _, exists := s.values[key]; !exists {
+		s.order = append(s.order, key)
+	}
+	s.values[key] = value
+}
+
+// take removes and returns key's raw value, so that once configFromTOML has
+// consumed every key it recognizes, whatever remains is unknown content to
+// preserve rather than silently drop.
+func (s *tomlSection) take(key string) (string, bool) {
+	v, ok := s.values[key]
+	if !ok {
+		return "", false
+	}
+	delete(s.values, key)
+	for i, k := range s.order {
+		if k == key {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return v, true
+}
+
+// parseTOML parses the minimal TOML subset wizado's config uses: [section]
+// headers (including dotted ones like [games.440]), and key = value lines
+// where value is a bare/quoted string, true/false, an integer, a float, or
+// a [a, b, c] array of any of those. Comments (#) and blank lines are
+// ignored. Multi-line values, inline tables, and dotted keys outside of
+// section headers are not supported.
+func parseTOML(data []byte) (*tomlDoc, error) {
+	doc := newTomlDoc()
+	current := doc.section("")
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			if name == "" {
+				return nil, fmt.Errorf("config: toml line %d: empty section header", lineNo)
+			}
+			current = doc.section(name)
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("config: toml line %d: expected key = value", lineNo)
+		}
+		key := strings.TrimSpace(line[:eq])
+		value := strings.TrimSpace(line[eq+1:])
+		current.set(key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func tomlUnquote(raw string) string {
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
+
+func tomlString(s *tomlSection, key, fallback string) string {
+	raw, ok := s.take(key)
+	if !ok {
+		return fallback
+	}
+	return tomlUnquote(raw)
+}
+
+func tomlBool(s *tomlSection, key string, fallback bool) bool {
+	raw, ok := s.take(key)
+	if !ok {
+		return fallback
+	}
+	return raw == "true"
+}
+
+func tomlInt(s *tomlSection, key string, fallback int) int {
+	raw, ok := s.take(key)
+	if !ok {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func tomlFloat(s *tomlSection, key string, fallback float64) float64 {
+	raw, ok := s.take(key)
+	if !ok {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func tomlArrayItems(raw string) []string {
+	inner := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(raw, "["), "]"))
+	if inner == "" {
+		return nil
+	}
+	parts := strings.Split(inner, ",")
+	items := make([]string, 0, len(parts))
+	for _, part := range parts {
+		items = append(items, strings.TrimSpace(part))
+	}
+	return items
+}
+
+func tomlIntSlice(s *tomlSection, key string, fallback []int) []int {
+	raw, ok := s.take(key)
+	if !ok {
+		return fallback
+	}
+	var out []int
+	for _, item := range tomlArrayItems(raw) {
+		if v, err := strconv.Atoi(item); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func tomlStringSlice(s *tomlSection, key string, fallback []string) []string {
+	raw, ok := s.take(key)
+	if !ok {
+		return fallback
+	}
+	var out []string
+	for _, item := range tomlArrayItems(raw) {
+		out = append(out, tomlUnquote(item))
+	}
+	return out
+}
+
+func formatTomlString(s string) string { return strconv.Quote(s) }
+
+func formatTomlBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func formatTomlInt(v int) string { return strconv.Itoa(v) }
+
+func formatTomlFloat(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+func formatTomlIntSlice(vs []int) string {
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = strconv.Itoa(v)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func formatTomlStringSlice(vs []string) string {
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = strconv.Quote(v)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// gamesSectionPrefix is the header prefix of a per-AppID override section,
+// e.g. "games.440".
+const gamesSectionPrefix = "games."
+
+// gameSectionAppID reports whether name is a "games.<appid>" section
+// header and, if so, returns the AppID part.
+func gameSectionAppID(name string) (string, bool) {
+	if !strings.HasPrefix(name, gamesSectionPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(name, gamesSectionPrefix), true
+}
+
+// tomlSectionOrder is the fixed order Save writes the known, non-game
+// sections in.
+var tomlSectionOrder = []string{"graphics", "steam", "hyprland", "mangohud", "sandbox", "enablements", "hooks"}
+
+// configFromTOML builds a Config from a parsed TOML document, consuming
+// every key it recognizes. Whatever's left - unknown keys in a known
+// section, or a whole section it doesn't know about - is kept on
+// cfg.extra so Save can write it straight back out.
+func configFromTOML(doc *tomlDoc) *Config {
+	cfg := Default()
+
+	top := doc.section("")
+	cfg.Version = tomlInt(top, "version", currentConfigVersion)
+
+	graphics := doc.section("graphics")
+	cfg.Resolution = tomlString(graphics, "resolution", cfg.Resolution)
+	cfg.FSR = tomlString(graphics, "fsr", cfg.FSR)
+	cfg.FrameLimit = tomlInt(graphics, "frame_limit", cfg.FrameLimit)
+	cfg.VRR = tomlBool(graphics, "vrr", cfg.VRR)
+	cfg.Scaler = tomlString(graphics, "scaler", cfg.Scaler)
+	cfg.Sharpness = tomlInt(graphics, "sharpness", cfg.Sharpness)
+	cfg.HDR = tomlBool(graphics, "hdr", cfg.HDR)
+	cfg.HDRPeakNits = tomlInt(graphics, "hdr_peak_nits", cfg.HDRPeakNits)
+	cfg.HDRITM = tomlBool(graphics, "hdr_itm", cfg.HDRITM)
+	cfg.MaxScale = tomlFloat(graphics, "max_scale", cfg.MaxScale)
+	cfg.Output = tomlString(graphics, "output", cfg.Output)
+	cfg.RefreshRate = tomlInt(graphics, "refresh_rate", cfg.RefreshRate)
+	cfg.RefreshRates = tomlIntSlice(graphics, "refresh_rates", cfg.RefreshRates)
+
+	steam := doc.section("steam")
+	cfg.SteamUI = tomlString(steam, "ui", cfg.SteamUI)
+	cfg.StreamingMode = tomlString(steam, "streaming_mode", cfg.StreamingMode)
+
+	hypr := doc.section("hyprland")
+	cfg.Workspace = tomlInt(hypr, "workspace", cfg.Workspace)
+
+	mango := doc.section("mangohud")
+	cfg.MangoHUD = tomlBool(mango, "enabled", cfg.MangoHUD)
+	cfg.MangoHUDSettings.Position = tomlString(mango, "position", cfg.MangoHUDSettings.Position)
+	cfg.MangoHUDSettings.FontSize = tomlInt(mango, "font_size", cfg.MangoHUDSettings.FontSize)
+	cfg.MangoHUDSettings.ShowFPSLimit = tomlBool(mango, "show_fps_limit", cfg.MangoHUDSettings.ShowFPSLimit)
+	cfg.MangoHUDSettings.ShowGPU = tomlBool(mango, "show_gpu", cfg.MangoHUDSettings.ShowGPU)
+	cfg.MangoHUDSettings.ShowCPU = tomlBool(mango, "show_cpu", cfg.MangoHUDSettings.ShowCPU)
+	cfg.MangoHUDSettings.ShowRAM = tomlBool(mango, "show_ram", cfg.MangoHUDSettings.ShowRAM)
+	cfg.MangoHUDSettings.ShowVRAM = tomlBool(mango, "show_vram", cfg.MangoHUDSettings.ShowVRAM)
+	cfg.MangoHUDSettings.ShowTemp = tomlBool(mango, "show_temp", cfg.MangoHUDSettings.ShowTemp)
+	cfg.MangoHUDSettings.ShowPower = tomlBool(mango, "show_power", cfg.MangoHUDSettings.ShowPower)
+	cfg.MangoHUDSettings.FrametimeGraph = tomlBool(mango, "frametime_graph", cfg.MangoHUDSettings.FrametimeGraph)
+	cfg.MangoHUDSettings.LogInterval = tomlInt(mango, "log_interval", cfg.MangoHUDSettings.LogInterval)
+	cfg.MangoHUDSettings.NoDisplay = tomlBool(mango, "no_display", cfg.MangoHUDSettings.NoDisplay)
+
+	sandbox := doc.section("sandbox")
+	cfg.SandboxEnabled = tomlBool(sandbox, "enabled", cfg.SandboxEnabled)
+	cfg.SandboxUser = tomlString(sandbox, "user", cfg.SandboxUser)
+
+	enable := doc.section("enablements")
+	cfg.EnableWayland = tomlBool(enable, "wayland", cfg.EnableWayland)
+	cfg.EnableX11 = tomlBool(enable, "x11", cfg.EnableX11)
+	cfg.EnablePulse = tomlBool(enable, "pulse", cfg.EnablePulse)
+	cfg.EnableDBus = tomlBool(enable, "dbus", cfg.EnableDBus)
+	cfg.EnableNetwork = tomlBool(enable, "network", cfg.EnableNetwork)
+	cfg.EnablePortal = tomlBool(enable, "portal", cfg.EnablePortal)
+	cfg.DBusProxyEnabled = tomlBool(enable, "dbus_proxy", cfg.DBusProxyEnabled)
+
+	hooks := doc.section("hooks")
+	cfg.HooksEnabled = tomlBool(hooks, "enabled", cfg.HooksEnabled)
+	cfg.Hooks.PreLaunch = tomlStringSlice(hooks, "pre_launch", cfg.Hooks.PreLaunch)
+	cfg.Hooks.PostLaunch = tomlStringSlice(hooks, "post_launch", cfg.Hooks.PostLaunch)
+	cfg.Hooks.OnCrash = tomlStringSlice(hooks, "on_crash", cfg.Hooks.OnCrash)
+
+	cfg.Games = map[string]GameOverride{}
+	for _, name := range doc.order {
+		appID, ok := gameSectionAppID(name)
+		if !ok {
+			continue
+		}
+		section := doc.section(name)
+		var override GameOverride
+		if raw, ok := section.take("fsr"); ok {
+			v := tomlUnquote(raw)
+			override.FSR = &v
+		}
+		if raw, ok := section.take("frame_limit"); ok {
+			if v, err := strconv.Atoi(raw); err == nil {
+				override.FrameLimit = &v
+			}
+		}
+		cfg.Games[appID] = override
+	}
+
+	cfg.extra = doc
+	return cfg
+}
+
+// tomlFromConfig encodes cfg as the current TOML format: the seven fixed
+// sections in a stable order, then one [games.<appid>] section per game
+// override, then whatever unrecognized content Load found on cfg.extra -
+// so a field this binary doesn't know about survives a load/save cycle.
+func tomlFromConfig(cfg *Config) []byte {
+	var b strings.Builder
+	b.WriteString("# Managed by wizado - edit by hand or via `wizado config`.\n")
+	fmt.Fprintf(&b, "version = %d\n\n", cfg.Version)
+
+	writeKnownKeys := func(lines []string) {
+		for _, line := range lines {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+
+	writeExtra := func(name string) {
+		if cfg.extra == nil {
+			return
+		}
+		s, ok := cfg.extra.sections[name]
+		if !ok {
+			return
+		}
+		for _, key := range s.order {
+			fmt.Fprintf(&b, "%s = %s\n", key, s.values[key])
+		}
+	}
+
+	writeSection := func(name string, lines []string) {
+		fmt.Fprintf(&b, "[%s]\n", name)
+		writeKnownKeys(lines)
+		writeExtra(name)
+		b.WriteString("\n")
+	}
+
+	writeSection("graphics", []string{
+		fmt.Sprintf("resolution = %s", formatTomlString(cfg.Resolution)),
+		fmt.Sprintf("fsr = %s", formatTomlString(cfg.FSR)),
+		fmt.Sprintf("frame_limit = %s", formatTomlInt(cfg.FrameLimit)),
+		fmt.Sprintf("vrr = %s", formatTomlBool(cfg.VRR)),
+		fmt.Sprintf("scaler = %s", formatTomlString(cfg.Scaler)),
+		fmt.Sprintf("sharpness = %s", formatTomlInt(cfg.Sharpness)),
+		fmt.Sprintf("hdr = %s", formatTomlBool(cfg.HDR)),
+		fmt.Sprintf("hdr_peak_nits = %s", formatTomlInt(cfg.HDRPeakNits)),
+		fmt.Sprintf("hdr_itm = %s", formatTomlBool(cfg.HDRITM)),
+		fmt.Sprintf("max_scale = %s", formatTomlFloat(cfg.MaxScale)),
+		fmt.Sprintf("output = %s", formatTomlString(cfg.Output)),
+		fmt.Sprintf("refresh_rate = %s", formatTomlInt(cfg.RefreshRate)),
+		fmt.Sprintf("refresh_rates = %s", formatTomlIntSlice(cfg.RefreshRates)),
+	})
+
+	writeSection("steam", []string{
+		fmt.Sprintf("ui = %s", formatTomlString(cfg.SteamUI)),
+		fmt.Sprintf("streaming_mode = %s", formatTomlString(cfg.StreamingMode)),
+	})
+
+	writeSection("hyprland", []string{
+		fmt.Sprintf("workspace = %s", formatTomlInt(cfg.Workspace)),
+	})
+
+	hud := cfg.MangoHUDSettings
+	writeSection("mangohud", []string{
+		fmt.Sprintf("enabled = %s", formatTomlBool(cfg.MangoHUD)),
+		fmt.Sprintf("position = %s", formatTomlString(hud.Position)),
+		fmt.Sprintf("font_size = %s", formatTomlInt(hud.FontSize)),
+		fmt.Sprintf("show_fps_limit = %s", formatTomlBool(hud.ShowFPSLimit)),
+		fmt.Sprintf("show_gpu = %s", formatTomlBool(hud.ShowGPU)),
+		fmt.Sprintf("show_cpu = %s", formatTomlBool(hud.ShowCPU)),
+		fmt.Sprintf("show_ram = %s", formatTomlBool(hud.ShowRAM)),
+		fmt.Sprintf("show_vram = %s", formatTomlBool(hud.ShowVRAM)),
+		fmt.Sprintf("show_temp = %s", formatTomlBool(hud.ShowTemp)),
+		fmt.Sprintf("show_power = %s", formatTomlBool(hud.ShowPower)),
+		fmt.Sprintf("frametime_graph = %s", formatTomlBool(hud.FrametimeGraph)),
+		fmt.Sprintf("log_interval = %s", formatTomlInt(hud.LogInterval)),
+		fmt.Sprintf("no_display = %s", formatTomlBool(hud.NoDisplay)),
+	})
+
+	writeSection("sandbox", []string{
+		fmt.Sprintf("enabled = %s", formatTomlBool(cfg.SandboxEnabled)),
+		fmt.Sprintf("user = %s", formatTomlString(cfg.SandboxUser)),
+	})
+
+	writeSection("enablements", []string{
+		fmt.Sprintf("wayland = %s", formatTomlBool(cfg.EnableWayland)),
+		fmt.Sprintf("x11 = %s", formatTomlBool(cfg.EnableX11)),
+		fmt.Sprintf("pulse = %s", formatTomlBool(cfg.EnablePulse)),
+		fmt.Sprintf("dbus = %s", formatTomlBool(cfg.EnableDBus)),
+		fmt.Sprintf("network = %s", formatTomlBool(cfg.EnableNetwork)),
+		fmt.Sprintf("portal = %s", formatTomlBool(cfg.EnablePortal)),
+		fmt.Sprintf("dbus_proxy = %s", formatTomlBool(cfg.DBusProxyEnabled)),
+	})
+
+	writeSection("hooks", []string{
+		fmt.Sprintf("enabled = %s", formatTomlBool(cfg.HooksEnabled)),
+		fmt.Sprintf("pre_launch = %s", formatTomlStringSlice(cfg.Hooks.PreLaunch)),
+		fmt.Sprintf("post_launch = %s", formatTomlStringSlice(cfg.Hooks.PostLaunch)),
+		fmt.Sprintf("on_crash = %s", formatTomlStringSlice(cfg.Hooks.OnCrash)),
+	})
+
+	gameIDs := make([]string, 0, len(cfg.Games))
+	for id := range cfg.Games {
+		gameIDs = append(gameIDs, id)
+	}
+	sort.Strings(gameIDs)
+	for _, id := range gameIDs {
+		override := cfg.Games[id]
+		name := gamesSectionPrefix + id
+		fmt.Fprintf(&b, "[%s]\n", name)
+		if override.FSR != nil {
+			fmt.Fprintf(&b, "fsr = %s\n", formatTomlString(*override.FSR))
+		}
+		if override.FrameLimit != nil {
+			fmt.Fprintf(&b, "frame_limit = %s\n", formatTomlInt(*override.FrameLimit))
+		}
+		writeExtra(name)
+		b.WriteString("\n")
+	}
+
+	if cfg.extra != nil {
+		known := map[string]bool{"": true}
+		for _, name := range tomlSectionOrder {
+			known[name] = true
+		}
+		for id := range cfg.Games {
+			known[gamesSectionPrefix+id] = true
+		}
+		for _, name := range cfg.extra.order {
+			if known[name] {
+				continue
+			}
+			s := cfg.extra.sections[name]
+			if len(s.order) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "[%s]\n", name)
+			for _, key := range s.order {
+				fmt.Fprintf(&b, "%s = %s\n", key, s.values[key])
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return []byte(b.String())
+}
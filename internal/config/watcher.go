@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wattfource/wizado/internal/logging"
+)
+
+var watcherLog = logging.WithComponent("config")
+
+// Watcher watches ~/.config/wizado/config and its conf.d/*.conf drop-ins
+// for changes via inotify, re-merges them (see LoadMerged), and notifies
+// subscribers with the resulting Config - so long-lived consumers like the
+// TUI and the gamescope launcher can react to an edited config without
+// restarting. Mirrors license.Manager's Subscribe/Snapshot shape.
+type Watcher struct {
+	mu       sync.RWMutex
+	latest   *Config
+	watchers []func(*Config)
+
+	fsWatcher *fsnotify.Watcher
+	refreshCh chan struct{}
+	stopCh    chan struct{}
+}
+
+// NewWatcher loads the current merged config, starts watching its
+// directories, and returns a Watcher. The caller must call Stop when done.
+func NewWatcher() (*Watcher, error) {
+	cfg, err := LoadMerged()
+	if err != nil {
+		return nil, err
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, _ := Paths()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+	if err := fsWatcher.Add(dir); err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	dropins := dropInDir()
+	if err := os.MkdirAll(dropins, 0755); err == nil {
+		fsWatcher.Add(dropins)
+	}
+
+	w := &Watcher{
+		latest:    cfg,
+		fsWatcher: fsWatcher,
+		refreshCh: make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Subscribe registers fn to be called, from the Watcher's goroutine,
+// whenever a reload produces a valid Config. fn is also called once
+// immediately with the current snapshot so subscribers don't have to wait
+// for the first change.
+func (w *Watcher) Subscribe(fn func(*Config)) {
+	w.mu.Lock()
+	w.watchers = append(w.watchers, fn)
+	current := w.latest
+	w.mu.Unlock()
+
+	fn(current)
+}
+
+// Snapshot returns the most recently loaded, validated Config without
+// touching disk.
+func (w *Watcher) Snapshot() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.latest
+}
+
+// Refresh forces an immediate reload instead of waiting for the next
+// filesystem event.
+func (w *Watcher) Refresh() {
+	select {
+	case w.refreshCh <- struct{}{}:
+	default:
+		// a reload is already pending
+	}
+}
+
+// Stop terminates the background goroutine and closes the underlying
+// inotify watch. The Watcher must not be used afterwards.
+func (w *Watcher) Stop() {
+	close(w.stopCh)
+	w.fsWatcher.Close()
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+				w.reload()
+			}
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			watcherLog.Warnf("config watcher error: %v", err)
+		case <-w.refreshCh:
+			w.reload()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// reload re-merges the config from disk and notifies subscribers, unless
+// the result fails Validate - an in-progress edit that briefly produces an
+// invalid FSR mode or workspace number shouldn't reach subscribers, so the
+// last good Config stays in effect until the file is fixed.
+func (w *Watcher) reload() {
+	cfg, err := LoadMerged()
+	if err != nil {
+		watcherLog.Warnf("config reload failed: %v", err)
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		watcherLog.Warnf("config reload rejected: %v", err)
+		return
+	}
+
+	w.mu.Lock()
+	w.latest = cfg
+	watchers := append([]func(*Config){}, w.watchers...)
+	w.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn(cfg)
+	}
+}
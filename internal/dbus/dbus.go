@@ -0,0 +1,146 @@
+// Package dbus wraps xdg-dbus-proxy to filter which session-bus names and
+// methods a sandboxed Steam session can reach, instead of exposing the full
+// desktop bus.
+package dbus
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/wattfource/wizado/internal/logging"
+)
+
+var log *logging.Logger
+
+func init() {
+	log = logging.WithComponent("dbus")
+}
+
+// Policy describes the own/talk/see/broadcast/call rules passed to
+// xdg-dbus-proxy's --filter mode. Each entry is a bus name or call rule as
+// xdg-dbus-proxy expects it (wildcards like "org.freedesktop.portal.*" are
+// supported).
+type Policy struct {
+	Own       []string
+	Talk      []string
+	See       []string
+	Broadcast []string
+	Call      []string
+}
+
+// DefaultPolicy is the Steam session's default bus policy: it can reach the
+// notification and portal services it needs, and own its own well-known
+// names, but can't talk to or own arbitrary names on the bus.
+func DefaultPolicy() Policy {
+	return Policy{
+		Own: []string{"com.valvesoftware.Steam*"},
+		Talk: []string{
+			"org.freedesktop.Notifications",
+			"org.freedesktop.portal.*",
+			"org.freedesktop.ScreenSaver",
+		},
+	}
+}
+
+func (p Policy) args() []string {
+	var args []string
+	for _, name := range p.Own {
+		args = append(args, "--own="+name)
+	}
+	for _, name := range p.Talk {
+		args = append(args, "--talk="+name)
+	}
+	for _, name := range p.See {
+		args = append(args, "--see="+name)
+	}
+	for _, name := range p.Broadcast {
+		args = append(args, "--broadcast="+name)
+	}
+	for _, name := range p.Call {
+		args = append(args, "--call="+name)
+	}
+	return args
+}
+
+// Proxy is a running xdg-dbus-proxy instance filtering access to the real
+// session bus.
+type Proxy struct {
+	cmd        *exec.Cmd
+	SocketPath string
+}
+
+// Start spawns xdg-dbus-proxy in front of the real session bus, enforcing
+// policy, and returns once the proxied socket is ready to use. Call Stop
+// when the session ends.
+func Start(sessionID string, policy Policy) (*Proxy, error) {
+	realBus := os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+	if realBus == "" {
+		return nil, fmt.Errorf("no DBUS_SESSION_BUS_ADDRESS to proxy")
+	}
+	if _, err := exec.LookPath("xdg-dbus-proxy"); err != nil {
+		return nil, fmt.Errorf("xdg-dbus-proxy not installed")
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = os.TempDir()
+	}
+	sockDir := filepath.Join(runtimeDir, "wizado")
+	if err := os.MkdirAll(sockDir, 0700); err != nil {
+		return nil, err
+	}
+	sockPath := filepath.Join(sockDir, fmt.Sprintf("dbus-proxy-%s.sock", sessionID))
+	os.Remove(sockPath)
+
+	args := append([]string{realBus, sockPath, "--filter"}, policy.args()...)
+	cmd := exec.Command("xdg-dbus-proxy", args...)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting xdg-dbus-proxy: %w", err)
+	}
+
+	if !waitForSocket(sockPath, 2*time.Second) {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, fmt.Errorf("xdg-dbus-proxy did not create %s in time", sockPath)
+	}
+
+	log.Infof("D-Bus proxy (pid %d) listening at %s", cmd.Process.Pid, sockPath)
+	return &Proxy{cmd: cmd, SocketPath: sockPath}, nil
+}
+
+// Env returns the DBUS_SESSION_BUS_ADDRESS value pointing at the proxy.
+func (p *Proxy) Env() string {
+	return "unix:path=" + p.SocketPath
+}
+
+// PID returns the proxy process's PID, for crash-reap registries.
+func (p *Proxy) PID() int {
+	if p.cmd == nil || p.cmd.Process == nil {
+		return 0
+	}
+	return p.cmd.Process.Pid
+}
+
+// Stop kills the proxy process and removes its socket.
+func (p *Proxy) Stop() {
+	if p.cmd != nil && p.cmd.Process != nil {
+		p.cmd.Process.Kill()
+		p.cmd.Wait()
+	}
+	os.Remove(p.SocketPath)
+}
+
+func waitForSocket(path string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
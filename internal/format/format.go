@@ -0,0 +1,184 @@
+// Package format renders byte counts, resolutions, counts, and durations for
+// display, with a pluggable locale so the same values can be shown in a
+// user's preferred thousands/decimal separators and unit names instead of
+// every call site hardcoding English/IEC conventions.
+package format
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Formatter renders values for display in a particular locale.
+type Formatter interface {
+	// Bytes renders n using IEC binary units (KiB, MiB, ...), e.g. "512 MiB".
+	Bytes(n uint64) string
+	// Resolution renders "WxH @ HzHz", preserving fractional refresh rates
+	// to up to three digits with trailing zeros trimmed (59.94, 60).
+	Resolution(w, h int, hz float64) string
+	// Count renders n with the locale's thousands separator, e.g. "1,234,567".
+	Count(n int64) string
+	// Duration renders d as "1h2m3s"-style, trimming zero leading units.
+	Duration(d time.Duration) string
+}
+
+// localeSpec holds the CLDR-ish bits that vary by locale: separators and
+// translated IEC byte-unit suffixes.
+type localeSpec struct {
+	DecimalSeparator   string
+	ThousandsSeparator string
+	ByteUnits          []string // indexed by power of 1024: B, KiB, MiB, GiB, TiB, PiB
+}
+
+// locales holds the known locale specs, keyed by short tag ("en", "de", "fr").
+var locales = map[string]localeSpec{
+	"en": {
+		DecimalSeparator:   ".",
+		ThousandsSeparator: ",",
+		ByteUnits:          []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"},
+	},
+	"de": {
+		DecimalSeparator:   ",",
+		ThousandsSeparator: ".",
+		ByteUnits:          []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"},
+	},
+	"fr": {
+		DecimalSeparator:   ",",
+		ThousandsSeparator: " ",
+		ByteUnits:          []string{"o", "Kio", "Mio", "Gio", "Tio", "Pio"},
+	},
+}
+
+// localeFormatter is the default Formatter implementation, driven entirely
+// by a localeSpec.
+type localeFormatter struct {
+	spec localeSpec
+}
+
+// For returns the Formatter for locale, falling back to "en" for unknown
+// locale tags.
+func For(locale string) Formatter {
+	spec, ok := locales[locale]
+	if !ok {
+		spec = locales["en"]
+	}
+	return localeFormatter{spec: spec}
+}
+
+func (f localeFormatter) Bytes(n uint64) string {
+	units := f.spec.ByteUnits
+	if n < 1024 {
+		return strconv.FormatUint(n, 10) + " " + units[0]
+	}
+
+	value := float64(n)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	return formatFixed(value, f.spec.DecimalSeparator) + " " + units[unit]
+}
+
+func (f localeFormatter) Resolution(w, h int, hz float64) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(w))
+	b.WriteString("x")
+	b.WriteString(strconv.Itoa(h))
+	b.WriteString(" @ ")
+	hzStr := strconv.FormatFloat(hz, 'f', 3, 64)
+	hzStr = strings.TrimRight(strings.TrimRight(hzStr, "0"), ".")
+	b.WriteString(strings.Replace(hzStr, ".", f.spec.DecimalSeparator, 1))
+	b.WriteString("Hz")
+	return b.String()
+}
+
+func (f localeFormatter) Count(n int64) string {
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	digits := strconv.FormatInt(n, 10)
+
+	var groups []string
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+
+	out := strings.Join(groups, f.spec.ThousandsSeparator)
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+func (f localeFormatter) Duration(d time.Duration) string {
+	if d < 0 {
+		return "-" + f.Duration(-d)
+	}
+
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+
+	var b strings.Builder
+	if h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "h")
+	}
+	if h > 0 || m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "m")
+	}
+	b.WriteString(strconv.FormatInt(int64(s), 10) + "s")
+	return b.String()
+}
+
+// formatFixed renders value with up to one fractional digit, trimming a
+// trailing ".0", using sep as the decimal separator.
+func formatFixed(value float64, sep string) string {
+	rounded := int64(value*10 + 0.5)
+	whole := rounded / 10
+	frac := rounded % 10
+
+	if frac == 0 {
+		return strconv.FormatInt(whole, 10)
+	}
+	return strconv.FormatInt(whole, 10) + sep + strconv.FormatInt(frac, 10)
+}
+
+var (
+	defaultFormatter Formatter
+	once             sync.Once
+)
+
+// Init initializes the default Formatter for locale.
+func Init(locale string) {
+	once.Do(func() {
+		defaultFormatter = For(locale)
+	})
+}
+
+// Default returns the default Formatter, resolving it from WIZADO_LOCALE
+// (falling back to "en") if Init hasn't been called yet.
+func Default() Formatter {
+	if defaultFormatter == nil {
+		Init(locale())
+	}
+	return defaultFormatter
+}
+
+// locale resolves the default locale tag from WIZADO_LOCALE, falling back
+// to "en" when unset.
+func locale() string {
+	if l := strings.TrimSpace(os.Getenv("WIZADO_LOCALE")); l != "" {
+		return l
+	}
+	return "en"
+}
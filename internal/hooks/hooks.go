@@ -0,0 +1,59 @@
+// Package hooks runs the user's configured pre-launch, post-launch, and
+// on-crash shell commands around a gaming session - switching GPU profiles,
+// muting notifications, reverting compositor tweaks, and the like.
+package hooks
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/wattfource/wizado/internal/config"
+	"github.com/wattfource/wizado/internal/logging"
+)
+
+var log *logging.Logger
+
+func init() {
+	log = logging.WithComponent("hooks")
+}
+
+// RunPreLaunch runs cfg's pre_launch hooks in order, before Steam starts.
+func RunPreLaunch(cfg *config.Config) []error {
+	return Run(cfg.Hooks.PreLaunch, "pre_launch")
+}
+
+// RunPostLaunch runs cfg's post_launch hooks in order. These must run
+// whether the session exited cleanly or crashed, so callers also register
+// them with the state registry for `wizado reap` to finish if the process
+// that would have run them dies first.
+func RunPostLaunch(cfg *config.Config) []error {
+	return Run(cfg.Hooks.PostLaunch, "post_launch")
+}
+
+// RunOnCrash runs cfg's on_crash hooks, in addition to post_launch, only
+// when the session ended with a non-zero exit.
+func RunOnCrash(cfg *config.Config) []error {
+	return Run(cfg.Hooks.OnCrash, "on_crash")
+}
+
+// Run executes each command via the shell in order, logging and collecting
+// failures instead of aborting the sequence - one broken hook shouldn't stop
+// the rest from running.
+func Run(commands []string, stage string) []error {
+	var errs []error
+	for _, c := range commands {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+
+		if err := exec.Command("sh", "-c", c).Run(); err != nil {
+			log.Warnf("%s hook failed: %q: %v", stage, c, err)
+			errs = append(errs, fmt.Errorf("%s hook %q: %w", stage, c, err))
+		} else {
+			log.Debugf("%s hook ok: %q", stage, c)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,120 @@
+// Package humanize renders byte counts, integers, floats, and timestamps
+// for human-readable display, modeled on the ecosystem go-humanize
+// library. It's the low-level, locale-free formatting layer; internal/format
+// builds a localized Formatter registry on top of the same conventions.
+package humanize
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var siByteUnits = []string{"B", "kB", "MB", "GB", "TB", "PB"}
+var iecByteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+// Bytes renders n using SI decimal units (1000-based): "1.5 kB", "2 MB".
+func Bytes(n uint64) string {
+	return formatBytes(n, 1000, siByteUnits)
+}
+
+// IBytes renders n using IEC binary units (1024-based): "1.5 KiB", "2 MiB".
+func IBytes(n uint64) string {
+	return formatBytes(n, 1024, iecByteUnits)
+}
+
+func formatBytes(n uint64, base float64, units []string) string {
+	if n < uint64(base) {
+		return strconv.FormatUint(n, 10) + " " + units[0]
+	}
+
+	value := float64(n)
+	unit := 0
+	for value >= base && unit < len(units)-1 {
+		value /= base
+		unit++
+	}
+
+	return Ftoa(value, 1) + " " + units[unit]
+}
+
+// Comma renders n as a decimal integer with "," every three digits, e.g.
+// Comma(1234567) == "1,234,567".
+func Comma(n int64) string {
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	digits := strconv.FormatInt(n, 10)
+
+	var groups []string
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+
+	out := strings.Join(groups, ",")
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+// Ftoa renders f with up to prec fractional digits, trimming trailing
+// zeros (and a trailing decimal point).
+func Ftoa(f float64, prec int) string {
+	return FormatFloat(f, prec)
+}
+
+// FormatFloat renders f with up to prec fractional digits, trimming
+// trailing zeros (and a trailing decimal point).
+func FormatFloat(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimRight(s, ".")
+	}
+	return s
+}
+
+// RelTime renders t relative to time.Now() as "3 minutes ago" / "in 3
+// minutes", falling back to "just now" for anything under a minute.
+func RelTime(t time.Time) string {
+	d := time.Since(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var label string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n := int(d / time.Minute)
+		label = plural(n, "minute")
+	case d < 24*time.Hour:
+		n := int(d / time.Hour)
+		label = plural(n, "hour")
+	case d < 30*24*time.Hour:
+		n := int(d / (24 * time.Hour))
+		label = plural(n, "day")
+	default:
+		n := int(d / (30 * 24 * time.Hour))
+		label = plural(n, "month")
+	}
+
+	if future {
+		return "in " + label
+	}
+	return label + " ago"
+}
+
+func plural(n int, unit string) string {
+	s := strconv.Itoa(n) + " " + unit
+	if n != 1 {
+		s += "s"
+	}
+	return s
+}
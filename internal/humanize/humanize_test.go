@@ -0,0 +1,33 @@
+package humanize
+
+import "testing"
+
+func TestIBytes(t *testing.T) {
+	cases := map[uint64]string{
+		0:          "0 B",
+		1023:       "1023 B",
+		1024:       "1 KiB",
+		512 * 1024: "512 KiB",
+		1536 * 1024 * 1024: "1.5 GiB",
+	}
+	for in, want := range cases {
+		if got := IBytes(in); got != want {
+			t.Errorf("IBytes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	if got := Bytes(1500); got != "1.5 kB" {
+		t.Errorf("Bytes(1500) = %q, want %q", got, "1.5 kB")
+	}
+}
+
+func TestComma(t *testing.T) {
+	if got := Comma(1234567); got != "1,234,567" {
+		t.Errorf("Comma(1234567) = %q, want %q", got, "1,234,567")
+	}
+	if got := Comma(-42); got != "-42" {
+		t.Errorf("Comma(-42) = %q, want %q", got, "-42")
+	}
+}
@@ -0,0 +1,82 @@
+// Package compositor abstracts the Wayland compositor operations wizado needs
+// (monitor geometry, workspace switching, idle inhibition) so the launcher is
+// not hardcoded to Hyprland.
+package compositor
+
+// Monitor describes one connected display as reported by the compositor.
+type Monitor struct {
+	Name      string
+	Width     int
+	Height    int
+	RefreshHz float64
+	Scale     float64
+	VRR       bool
+}
+
+// IdleInhibitor controls a compositor's idle daemon (hypridle, swayidle, ...)
+// so it can be paused for the duration of a gaming session and restored after.
+type IdleInhibitor interface {
+	// Stop pauses the idle daemon if it was running, returning whether it was.
+	Stop() bool
+	// Start resumes the idle daemon.
+	Start()
+}
+
+// Compositor is the set of operations wizado needs from a Wayland compositor.
+type Compositor interface {
+	// Detect reports whether this backend matches the running session.
+	Detect() bool
+	Monitors() ([]Monitor, error)
+	ActiveWorkspace() (int, error)
+	Workspaces() ([]int, error)
+	Switch(id int) error
+	Idle() IdleInhibitor
+
+	// DisableOutput/EnableOutput toggle a single output, used to keep a gaming
+	// session confined to one monitor. Returns an error if unsupported.
+	DisableOutput(name string) error
+	EnableOutput(name string) error
+}
+
+// backends is tried in order; the first to report Detect() == true is used.
+// Generic is last since it matches any Wayland session.
+func backends() []Compositor {
+	return []Compositor{&Hyprland{}, &Sway{}, &Generic{}}
+}
+
+// Detect picks the Compositor backend matching the current session,
+// falling back to the generic wlr-based backend if nothing more specific matches.
+func Detect() Compositor {
+	for _, c := range backends() {
+		if c.Detect() {
+			return c
+		}
+	}
+	return &Generic{}
+}
+
+// FindEmptyWorkspace returns preferred if it is free, otherwise the first free
+// workspace in 1-10, or preferred itself if none are free or Workspaces fails.
+func FindEmptyWorkspace(c Compositor, preferred int) int {
+	ids, err := c.Workspaces()
+	if err != nil {
+		return preferred
+	}
+
+	used := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		used[id] = true
+	}
+
+	if !used[preferred] {
+		return preferred
+	}
+
+	for i := 1; i <= 10; i++ {
+		if !used[i] {
+			return i
+		}
+	}
+
+	return preferred
+}
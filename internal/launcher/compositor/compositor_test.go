@@ -0,0 +1,41 @@
+package compositor
+
+import "testing"
+
+type fakeCompositor struct {
+	workspaces []int
+}
+
+func (f *fakeCompositor) Detect() bool                 { return true }
+func (f *fakeCompositor) Monitors() ([]Monitor, error)  { return nil, nil }
+func (f *fakeCompositor) ActiveWorkspace() (int, error) { return 0, nil }
+func (f *fakeCompositor) Workspaces() ([]int, error)    { return f.workspaces, nil }
+func (f *fakeCompositor) Switch(id int) error           { return nil }
+func (f *fakeCompositor) Idle() IdleInhibitor           { return noopIdle{} }
+func (f *fakeCompositor) DisableOutput(name string) error { return nil }
+func (f *fakeCompositor) EnableOutput(name string) error  { return nil }
+
+func TestFindEmptyWorkspacePreferredFree(t *testing.T) {
+	c := &fakeCompositor{workspaces: []int{1, 2, 3}}
+	if got := FindEmptyWorkspace(c, 10); got != 10 {
+		t.Errorf("FindEmptyWorkspace() = %d, want 10", got)
+	}
+}
+
+func TestFindEmptyWorkspacePreferredUsed(t *testing.T) {
+	c := &fakeCompositor{workspaces: []int{1, 2, 10}}
+	if got := FindEmptyWorkspace(c, 10); got != 3 {
+		t.Errorf("FindEmptyWorkspace() = %d, want 3", got)
+	}
+}
+
+func TestFindEmptyWorkspaceAllUsedFallsBackToPreferred(t *testing.T) {
+	used := make([]int, 10)
+	for i := range used {
+		used[i] = i + 1
+	}
+	c := &fakeCompositor{workspaces: used}
+	if got := FindEmptyWorkspace(c, 10); got != 10 {
+		t.Errorf("FindEmptyWorkspace() = %d, want 10", got)
+	}
+}
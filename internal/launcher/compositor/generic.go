@@ -0,0 +1,108 @@
+package compositor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+// wlrModeRe matches a wlr-randr current mode line, e.g.
+// "    1920x1080px, 59.999996Hz (preferred, current)"
+var wlrModeRe = regexp.MustCompile(`(\d+)x(\d+)px, ([\d.]+)Hz \(.*current.*\)`)
+
+// Generic is the fallback backend for wlr-based compositors without a
+// dedicated implementation (river, wayfire, ...). It reports monitors via
+// wlr-randr but has no concept of workspaces, so workspace switching is a no-op
+// and idle inhibition is left to whatever the compositor does on its own.
+type Generic struct{}
+
+func (g *Generic) Detect() bool {
+	return os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+func (g *Generic) Monitors() ([]Monitor, error) {
+	out, err := exec.Command("wlr-randr").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseWlrRandr(string(out)), nil
+}
+
+func parseWlrRandr(output string) []Monitor {
+	var monitors []Monitor
+	var name string
+
+	for _, line := range splitLines(output) {
+		if len(line) > 0 && line[0] != ' ' && line[0] != '\t' {
+			name = firstField(line)
+			continue
+		}
+
+		m := wlrModeRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		width, _ := strconv.Atoi(m[1])
+		height, _ := strconv.Atoi(m[2])
+		hz, _ := strconv.ParseFloat(m[3], 64)
+		monitors = append(monitors, Monitor{Name: name, Width: width, Height: height, RefreshHz: hz})
+	}
+
+	return monitors
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i, r := range s {
+		if r == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
+
+func firstField(s string) string {
+	for i, r := range s {
+		if r == ' ' {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func (g *Generic) ActiveWorkspace() (int, error) {
+	return 0, fmt.Errorf("workspaces not supported by the generic compositor backend")
+}
+
+func (g *Generic) Workspaces() ([]int, error) {
+	return nil, fmt.Errorf("workspaces not supported by the generic compositor backend")
+}
+
+func (g *Generic) Switch(id int) error {
+	return nil
+}
+
+func (g *Generic) Idle() IdleInhibitor {
+	return noopIdle{}
+}
+
+func (g *Generic) DisableOutput(name string) error {
+	return fmt.Errorf("output control not supported by the generic compositor backend")
+}
+
+func (g *Generic) EnableOutput(name string) error {
+	return fmt.Errorf("output control not supported by the generic compositor backend")
+}
+
+type noopIdle struct{}
+
+func (noopIdle) Stop() bool { return false }
+func (noopIdle) Start()     {}
@@ -0,0 +1,116 @@
+package compositor
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// Hyprland drives a Hyprland session via hyprctl.
+type Hyprland struct{}
+
+func (h *Hyprland) Detect() bool {
+	return os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != ""
+}
+
+func (h *Hyprland) Monitors() ([]Monitor, error) {
+	out, err := exec.Command("hyprctl", "monitors", "-j").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Name        string  `json:"name"`
+		Width       int     `json:"width"`
+		Height      int     `json:"height"`
+		RefreshRate float64 `json:"refreshRate"`
+		Scale       float64 `json:"scale"`
+		VRR         bool    `json:"vrr"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, err
+	}
+
+	monitors := make([]Monitor, len(raw))
+	for i, m := range raw {
+		monitors[i] = Monitor{
+			Name:      m.Name,
+			Width:     m.Width,
+			Height:    m.Height,
+			RefreshHz: m.RefreshRate,
+			Scale:     m.Scale,
+			VRR:       m.VRR,
+		}
+	}
+	return monitors, nil
+}
+
+func (h *Hyprland) ActiveWorkspace() (int, error) {
+	out, err := exec.Command("hyprctl", "activeworkspace", "-j").Output()
+	if err != nil {
+		return 0, err
+	}
+
+	var ws struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(out, &ws); err != nil {
+		return 0, err
+	}
+	return ws.ID, nil
+}
+
+func (h *Hyprland) Workspaces() ([]int, error) {
+	out, err := exec.Command("hyprctl", "workspaces", "-j").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(raw))
+	for i, w := range raw {
+		ids[i] = w.ID
+	}
+	return ids, nil
+}
+
+func (h *Hyprland) Switch(id int) error {
+	return exec.Command("hyprctl", "dispatch", "workspace", strconv.Itoa(id)).Run()
+}
+
+func (h *Hyprland) Idle() IdleInhibitor {
+	return &hypridleInhibitor{}
+}
+
+func (h *Hyprland) DisableOutput(name string) error {
+	return exec.Command("hyprctl", "keyword", "monitor", name+",disable").Run()
+}
+
+func (h *Hyprland) EnableOutput(name string) error {
+	return exec.Command("hyprctl", "keyword", "monitor", name+",preferred,auto,auto").Run()
+}
+
+type hypridleInhibitor struct{}
+
+func (hypridleInhibitor) Stop() bool {
+	out, _ := exec.Command("pgrep", "-x", "hypridle").Output()
+	if len(out) > 0 {
+		exec.Command("pkill", "hypridle").Run()
+		return true
+	}
+	return false
+}
+
+func (hypridleInhibitor) Start() {
+	cmd := exec.Command("hypridle")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Start()
+}
@@ -0,0 +1,132 @@
+package compositor
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// Sway drives a Sway session via swaymsg.
+type Sway struct{}
+
+func (s *Sway) Detect() bool {
+	return os.Getenv("SWAYSOCK") != ""
+}
+
+func (s *Sway) Monitors() ([]Monitor, error) {
+	out, err := exec.Command("swaymsg", "-t", "get_outputs", "-r").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Name        string  `json:"name"`
+		Active      bool    `json:"active"`
+		Scale       float64 `json:"scale"`
+		AdaptiveSync string `json:"adaptive_sync_status"`
+		CurrentMode struct {
+			Width   int `json:"width"`
+			Height  int `json:"height"`
+			Refresh int `json:"refresh"` // mHz
+		} `json:"current_mode"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, err
+	}
+
+	var monitors []Monitor
+	for _, o := range raw {
+		if !o.Active {
+			continue
+		}
+		monitors = append(monitors, Monitor{
+			Name:      o.Name,
+			Width:     o.CurrentMode.Width,
+			Height:    o.CurrentMode.Height,
+			RefreshHz: float64(o.CurrentMode.Refresh) / 1000,
+			Scale:     o.Scale,
+			VRR:       o.AdaptiveSync == "enabled",
+		})
+	}
+	return monitors, nil
+}
+
+func (s *Sway) ActiveWorkspace() (int, error) {
+	workspaces, err := s.rawWorkspaces()
+	if err != nil {
+		return 0, err
+	}
+	for _, w := range workspaces {
+		if w.Focused {
+			return w.Num, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *Sway) Workspaces() ([]int, error) {
+	workspaces, err := s.rawWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(workspaces))
+	for i, w := range workspaces {
+		ids[i] = w.Num
+	}
+	return ids, nil
+}
+
+func (s *Sway) rawWorkspaces() ([]struct {
+	Num     int  `json:"num"`
+	Focused bool `json:"focused"`
+}, error) {
+	out, err := exec.Command("swaymsg", "-t", "get_workspaces", "-r").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []struct {
+		Num     int  `json:"num"`
+		Focused bool `json:"focused"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func (s *Sway) Switch(id int) error {
+	return exec.Command("swaymsg", "workspace", strconv.Itoa(id)).Run()
+}
+
+func (s *Sway) Idle() IdleInhibitor {
+	return &swayidleInhibitor{}
+}
+
+func (s *Sway) DisableOutput(name string) error {
+	return exec.Command("swaymsg", "output", name, "disable").Run()
+}
+
+func (s *Sway) EnableOutput(name string) error {
+	return exec.Command("swaymsg", "output", name, "enable").Run()
+}
+
+type swayidleInhibitor struct{}
+
+func (swayidleInhibitor) Stop() bool {
+	out, _ := exec.Command("pgrep", "-x", "swayidle").Output()
+	if len(out) > 0 {
+		exec.Command("pkill", "swayidle").Run()
+		return true
+	}
+	return false
+}
+
+func (swayidleInhibitor) Start() {
+	cmd := exec.Command("swayidle")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Start()
+}
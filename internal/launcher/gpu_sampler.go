@@ -0,0 +1,122 @@
+package launcher
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wattfource/wizado/internal/telemetry"
+)
+
+// gpuSampleInterval is how often live GPU metrics are recorded during a session
+const gpuSampleInterval = 2 * time.Second
+
+// sampleGPU periodically records GPU utilization/thermals into series until ctx is cancelled.
+// It must never panic if the GPU tools disappear mid-session - a failed read is just skipped.
+func sampleGPU(ctx context.Context, gpu GPUInfo, series *telemetry.GPUTimeSeries) {
+	ticker := time.NewTicker(gpuSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sample, ok := readGPUSample(gpu)
+			if ok {
+				series.Add(sample)
+			}
+		}
+	}
+}
+
+func readGPUSample(gpu GPUInfo) (telemetry.GPUSample, bool) {
+	if gpu.HasNVIDIA {
+		return readNVIDIASample()
+	}
+	if gpu.HasAMD {
+		return readAMDSample()
+	}
+	return telemetry.GPUSample{}, false
+}
+
+// readNVIDIASample shells out to nvidia-smi; no NVML/cgo dependency is available in this build
+func readNVIDIASample() (telemetry.GPUSample, bool) {
+	out, err := exec.Command("nvidia-smi",
+		"--query-gpu=utilization.gpu,utilization.memory,temperature.gpu,power.draw,clocks.gr,clocks.mem,memory.used",
+		"--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return telemetry.GPUSample{}, false
+	}
+
+	fields := strings.Split(strings.TrimSpace(string(out)), ",")
+	if len(fields) < 7 {
+		return telemetry.GPUSample{}, false
+	}
+
+	return telemetry.GPUSample{
+		Timestamp:   time.Now().UTC(),
+		UtilGPU:     parseFloatField(fields[0]),
+		UtilMemory:  parseFloatField(fields[1]),
+		TempC:       parseFloatField(fields[2]),
+		PowerW:      parseFloatField(fields[3]),
+		ClockGrMHz:  parseFloatField(fields[4]),
+		ClockMemMHz: parseFloatField(fields[5]),
+		MemUsedMiB:  parseFloatField(fields[6]),
+	}, true
+}
+
+// readAMDSample reads the first card's sysfs hwmon files; layout varies by kernel/amdgpu version
+// so every read is best-effort and a missing file simply leaves that field zero.
+func readAMDSample() (telemetry.GPUSample, bool) {
+	cardDir := findAMDCardDir()
+	if cardDir == "" {
+		return telemetry.GPUSample{}, false
+	}
+
+	sample := telemetry.GPUSample{Timestamp: time.Now().UTC()}
+
+	if busy, err := os.ReadFile(filepath.Join(cardDir, "gpu_busy_percent")); err == nil {
+		sample.UtilGPU = parseFloatField(string(busy))
+	}
+
+	hwmonDir := findHwmonDir(cardDir)
+	if hwmonDir != "" {
+		if temp, err := os.ReadFile(filepath.Join(hwmonDir, "temp1_input")); err == nil {
+			sample.TempC = parseFloatField(string(temp)) / 1000
+		}
+		if power, err := os.ReadFile(filepath.Join(hwmonDir, "power1_average")); err == nil {
+			sample.PowerW = parseFloatField(string(power)) / 1_000_000
+		}
+	}
+
+	return sample, true
+}
+
+func findAMDCardDir() string {
+	matches, _ := filepath.Glob("/sys/class/drm/card*/device/gpu_busy_percent")
+	if len(matches) == 0 {
+		return ""
+	}
+	return filepath.Dir(matches[0])
+}
+
+func findHwmonDir(cardDir string) string {
+	matches, _ := filepath.Glob(filepath.Join(cardDir, "hwmon", "hwmon*"))
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[0]
+}
+
+func parseFloatField(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
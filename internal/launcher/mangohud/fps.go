@@ -0,0 +1,90 @@
+package mangohud
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FPSStats summarizes a session's frame-time log parsed from the MangoHUD CSV.
+type FPSStats struct {
+	Frames  int
+	AvgFPS  float64
+	Low1FPS  float64 // average FPS of the worst 1% of frames
+	Low01FPS float64 // average FPS of the worst 0.1% of frames
+}
+
+// ParseCSV reads a MangoHUD per-frame CSV log and computes average/1%/0.1% low FPS.
+func ParseCSV(path string) (FPSStats, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FPSStats{}, err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) < 2 {
+		return FPSStats{}, fmt.Errorf("no frame data in %s", path)
+	}
+
+	fpsCol := -1
+	for i, h := range strings.Split(lines[0], ",") {
+		if strings.EqualFold(strings.TrimSpace(h), "fps") {
+			fpsCol = i
+			break
+		}
+	}
+	if fpsCol == -1 {
+		return FPSStats{}, fmt.Errorf("no fps column in %s", path)
+	}
+
+	var values []float64
+	for _, line := range lines[1:] {
+		fields := strings.Split(line, ",")
+		if fpsCol >= len(fields) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(fields[fpsCol]), 64)
+		if err != nil {
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return FPSStats{}, fmt.Errorf("no usable fps samples in %s", path)
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return FPSStats{
+		Frames:   len(values),
+		AvgFPS:   sum / float64(len(values)),
+		Low1FPS:  lowPercentileAvg(sorted, 0.01),
+		Low01FPS: lowPercentileAvg(sorted, 0.001),
+	}, nil
+}
+
+// lowPercentileAvg averages the bottom fraction p of sorted (ascending) FPS
+// values - this is the conventional "1% low" / "0.1% low" smoothness metric.
+func lowPercentileAvg(sorted []float64, p float64) float64 {
+	n := int(float64(len(sorted)) * p)
+	if n < 1 {
+		n = 1
+	}
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+
+	sum := 0.0
+	for _, v := range sorted[:n] {
+		sum += v
+	}
+	return sum / float64(n)
+}
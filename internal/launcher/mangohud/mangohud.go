@@ -0,0 +1,102 @@
+// Package mangohud materialises a session-scoped MangoHUD config so overlay
+// settings and per-frame CSV logging can vary per gaming session instead of
+// relying on the user's global ~/.config/MangoHud/MangoHud.conf.
+package mangohud
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wattfource/wizado/internal/config"
+)
+
+// Session is an ephemeral MangoHUD config created for one gaming session.
+type Session struct {
+	ConfigPath string
+	CSVPath    string
+}
+
+// Start writes a session-scoped MangoHUD config under $XDG_RUNTIME_DIR and
+// points it at csvPath for per-frame logging. Call Cleanup when the session ends.
+func Start(cfg config.MangoHUD, sessionID, csvPath string) (*Session, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = filepath.Join(os.TempDir(), "wizado-runtime")
+	}
+
+	confDir := filepath.Join(runtimeDir, "wizado")
+	if err := os.MkdirAll(confDir, 0700); err != nil {
+		return nil, err
+	}
+
+	confPath := filepath.Join(confDir, fmt.Sprintf("mangohud-%s.conf", sessionID))
+	if err := os.WriteFile(confPath, []byte(buildConfig(cfg, csvPath)), 0600); err != nil {
+		return nil, err
+	}
+
+	return &Session{ConfigPath: confPath, CSVPath: csvPath}, nil
+}
+
+// Env returns the environment variables needed to point MangoHUD at this session's config.
+func (s *Session) Env() []string {
+	return []string{"MANGOHUD_CONFIGFILE=" + s.ConfigPath}
+}
+
+// Cleanup removes the session's ephemeral config file.
+func (s *Session) Cleanup() {
+	os.Remove(s.ConfigPath)
+}
+
+// Update rewrites the session's MangoHUD config in place with new overlay
+// settings, keeping the same CSV output path. MangoHUD watches its config
+// file itself and picks up the change without the game or Steam needing a
+// restart, unlike resolution/FSR changes which require a fresh gamescope.
+func (s *Session) Update(cfg config.MangoHUD) error {
+	return os.WriteFile(s.ConfigPath, []byte(buildConfig(cfg, s.CSVPath)), 0600)
+}
+
+func buildConfig(cfg config.MangoHUD, csvPath string) string {
+	var lines []string
+
+	lines = append(lines, "position="+cfg.Position)
+	lines = append(lines, fmt.Sprintf("font_size=%d", cfg.FontSize))
+
+	if cfg.NoDisplay {
+		lines = append(lines, "no_display")
+	}
+	if cfg.ShowFPSLimit {
+		lines = append(lines, "fps")
+	}
+	if cfg.ShowGPU {
+		lines = append(lines, "gpu_stats")
+	}
+	if cfg.ShowCPU {
+		lines = append(lines, "cpu_stats")
+	}
+	if cfg.ShowRAM {
+		lines = append(lines, "ram")
+	}
+	if cfg.ShowVRAM {
+		lines = append(lines, "vram")
+	}
+	if cfg.ShowTemp {
+		lines = append(lines, "gpu_temp", "cpu_temp")
+	}
+	if cfg.ShowPower {
+		lines = append(lines, "gpu_power", "cpu_power")
+	}
+	if cfg.FrametimeGraph {
+		lines = append(lines, "frametime")
+	}
+	if cfg.LogInterval > 0 {
+		lines = append(lines, fmt.Sprintf("log_interval=%d", cfg.LogInterval))
+	}
+	if csvPath != "" {
+		lines = append(lines, "output_folder="+filepath.Dir(csvPath))
+		lines = append(lines, "log_name="+strings.TrimSuffix(filepath.Base(csvPath), filepath.Ext(csvPath)))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
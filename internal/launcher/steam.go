@@ -2,9 +2,9 @@
 package launcher
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"math"
 	"os"
@@ -12,11 +12,17 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
+	"sync"
 	"time"
 
 	"github.com/wattfource/wizado/internal/config"
+	"github.com/wattfource/wizado/internal/dbus"
+	"github.com/wattfource/wizado/internal/hooks"
+	"github.com/wattfource/wizado/internal/launcher/compositor"
+	"github.com/wattfource/wizado/internal/launcher/mangohud"
 	"github.com/wattfource/wizado/internal/logging"
+	"github.com/wattfource/wizado/internal/sandbox"
+	"github.com/wattfource/wizado/internal/state"
 	"github.com/wattfource/wizado/internal/sysinfo"
 	"github.com/wattfource/wizado/internal/telemetry"
 )
@@ -64,43 +70,153 @@ func Launch(cfg *config.Config) error {
 		return err
 	}
 	
-	// Check requirements
-	if err := checkRequirements(); err != nil {
+	// Check requirements and pick a compositor backend
+	comp, err := checkRequirements()
+	if err != nil {
 		log.Errorf("Requirements check failed: %v", err)
 		return err
 	}
-	
+
 	// Detect GPU
 	session.GPU = detectGPU()
 	log.Infof("GPU detected: NVIDIA=%v AMD=%v Intel=%v", session.GPU.HasNVIDIA, session.GPU.HasAMD, session.GPU.HasIntel)
-	
-	// Get resolution
-	session.Width, session.Height = getResolution(cfg)
-	log.Infof("Using resolution: %dx%d", session.Width, session.Height)
-	
+
+	// Select target monitor and resolution
+	monitor, otherMonitors := selectMonitor(cfg, comp)
+	session.Width, session.Height = monitor.Width, monitor.Height
+	log.Infof("Using resolution: %dx%d on output %q", session.Width, session.Height, monitor.Name)
+
+	// Apply any [games.<appid>] override for the title being launched, so a
+	// handful of troublesome games can pin a different FSR mode or frame
+	// limit without changing the global default. WIZADO_APPID is set by the
+	// Steam launch wrapper, or by the TUI's game library screen.
+	appID := os.Getenv("WIZADO_APPID")
+	cfg = applyGameOverride(cfg, appID)
+
+	// Layer a per-game profile (edited from the TUI's game library screen
+	// and stored under ~/.config/wizado/profiles/<appid>.conf) on top -
+	// profiles are the more specific of the two per-game override
+	// mechanisms, so they take precedence.
+	if appID != "" {
+		if profiled, err := config.LoadProfile(cfg, appID); err == nil {
+			cfg = profiled
+		}
+	}
+
 	// Build gamescope args
-	gsArgs := buildGamescopeArgs(cfg, session.GPU, session.Width, session.Height)
-	
+	gsArgs := buildGamescopeArgs(cfg, session.GPU, monitor)
+
 	// Kill existing Steam
 	log.Debug("Killing any existing Steam processes")
 	killSteam()
-	
-	// Stop hypridle if running
-	hypridleWasRunning := stopHypridle()
-	if hypridleWasRunning {
-		log.Debug("Stopped hypridle")
+
+	// Stop the compositor's idle daemon if running
+	idle := comp.Idle()
+	idleWasRunning := idle.Stop()
+	if idleWasRunning {
+		log.Debug("Stopped idle inhibitor")
 	}
-	
+
+	// Confine the session to the target output
+	for _, m := range otherMonitors {
+		if err := comp.DisableOutput(m.Name); err != nil {
+			log.Debugf("Could not disable output %s: %v", m.Name, err)
+		} else {
+			log.Infof("Disabled output %s for the gaming session", m.Name)
+		}
+	}
+
 	// Save current workspace
-	originalWorkspace := getCurrentWorkspace()
-	
+	originalWorkspace, err := comp.ActiveWorkspace()
+	if err != nil {
+		originalWorkspace = 1
+	}
+
 	// Find target workspace
-	targetWorkspace := findEmptyWorkspace(cfg.Workspace)
+	targetWorkspace := compositor.FindEmptyWorkspace(comp, cfg.Workspace)
 	log.Infof("Using workspace %d (original: %d)", targetWorkspace, originalWorkspace)
 	
 	// Set up environment
 	env := setupEnvironment(cfg, session.GPU)
-	
+
+	// Materialise a session-scoped MangoHUD config so overlay/logging settings
+	// don't depend on the user's global MangoHud.conf
+	var hudSession *mangohud.Session
+	var hudCSVPath string
+	if cfg.MangoHUD {
+		home, _ := os.UserHomeDir()
+		hudCSVPath = filepath.Join(home, ".cache", "wizado", "sessions", fmt.Sprintf("session_%s.mangohud.csv", session.ID))
+		var err error
+		hudSession, err = mangohud.Start(cfg.MangoHUDSettings, session.ID, hudCSVPath)
+		if err != nil {
+			log.Warnf("Failed to write MangoHUD config: %v", err)
+		} else {
+			env = append(env, hudSession.Env()...)
+		}
+	}
+
+	// Watch for config changes for the life of the session so MangoHUD
+	// overlay/logging settings can be edited without restarting - MangoHUD
+	// reloads its own config file on a write. Resolution, FSR, and
+	// workspace are baked into the gamescope command line above and still
+	// require relaunching the session to change.
+	var configWatcher *config.Watcher
+	if w, err := config.NewWatcher(); err == nil {
+		configWatcher = w
+		configWatcher.Subscribe(func(updated *config.Config) {
+			if hudSession == nil || !updated.MangoHUD {
+				return
+			}
+			if err := hudSession.Update(updated.MangoHUDSettings); err != nil {
+				log.Warnf("Could not apply updated MangoHUD settings: %v", err)
+			} else {
+				log.Info("Applied updated MangoHUD settings without restarting the session")
+			}
+		})
+	} else {
+		log.Warnf("Could not watch config for live updates: %v", err)
+	}
+
+	// Filter the session bus through xdg-dbus-proxy instead of exposing it directly
+	var dbusProxy *dbus.Proxy
+	if cfg.DBusProxyEnabled && cfg.EnableDBus {
+		var err error
+		dbusProxy, err = dbus.Start(session.ID, dbus.DefaultPolicy())
+		if err != nil {
+			log.Warnf("Could not start D-Bus proxy: %v", err)
+		} else {
+			env = setEnvVar(env, "DBUS_SESSION_BUS_ADDRESS", dbusProxy.Env())
+		}
+	}
+
+	// Grant the sandbox user access to this session's Wayland/audio sockets
+	var sb *sandbox.Sandbox
+	if cfg.SandboxEnabled {
+		sandboxUser := cfg.SandboxUser
+		if sandboxUser == "" {
+			sandboxUser = sandbox.DefaultUser
+		}
+		if err := sandbox.EnsureUser(sandboxUser); err != nil {
+			log.Warnf("Could not provision sandbox user: %v", err)
+		} else {
+			home, _ := os.UserHomeDir()
+			sb, err = sandbox.Prepare(sandboxUser,
+				filepath.Join(home, ".steam"),
+				filepath.Join(home, ".local", "share", "Steam"),
+			)
+			if err != nil {
+				log.Warnf("Sandbox prepare failed: %v", err)
+			}
+		}
+	}
+
+	// Run pre-launch hooks (GPU profile switches, compositor tweaks, ...)
+	if cfg.HooksEnabled {
+		if errs := hooks.RunPreLaunch(cfg); len(errs) > 0 {
+			log.Warnf("%d pre_launch hook(s) failed", len(errs))
+		}
+	}
+
 	// Start GameMode if available
 	session.GameModeActive = startGameMode()
 	if session.GameModeActive {
@@ -108,7 +224,7 @@ func Launch(cfg *config.Config) error {
 	}
 	
 	// Switch to gaming workspace
-	switchWorkspace(targetWorkspace)
+	comp.Switch(targetWorkspace)
 	
 	// Build full command
 	steamUI := cfg.SteamUI
@@ -116,12 +232,15 @@ func Launch(cfg *config.Config) error {
 		steamUI = "gamepadui"
 	}
 	steamArgs := []string{"-" + steamUI, "-steamos3", "-steampal", "-steamdeck"}
+	if appID != "" {
+		steamArgs = append(steamArgs, "-applaunch", appID)
+	}
 	fullArgs := append(gsArgs, "--")
 	fullArgs = append(fullArgs, "steam")
 	fullArgs = append(fullArgs, steamArgs...)
 	
 	// Create log file for this session
-	logFile := createLogFile(session.ID)
+	logFile, logPath := createLogFile(session.ID)
 	if logFile != nil {
 		fmt.Fprintf(logFile, "═══════════════════════════════════════════════════════════════\n")
 		fmt.Fprintf(logFile, "  Wizado Gaming Session: %s\n", session.ID)
@@ -155,19 +274,99 @@ func Launch(cfg *config.Config) error {
 		SteamUI:    steamUI,
 	}
 	telemetry.RecordLaunch(telemetrySession)
-	
-	// Launch gamescope + Steam
-	cmd := exec.Command("gamescope", fullArgs...)
+
+	// Sample GPU load/thermals for the duration of the session
+	gpuCtx, cancelGPUSampler := context.WithCancel(context.Background())
+	gpuSeries := telemetry.NewGPUTimeSeries()
+	go sampleGPU(gpuCtx, session.GPU, gpuSeries)
+
+	// Watch for Remote Play / Steam Link clients connecting mid-session
+	var streamingMonitor *StreamingMonitor
+	var cancelStreamingWatch context.CancelFunc
+	if cfg.StreamingMode != "off" {
+		streamingMonitor = &StreamingMonitor{}
+		var streamCtx context.Context
+		streamCtx, cancelStreamingWatch = context.WithCancel(context.Background())
+		go watchStreamingLog(streamCtx, streamingMonitor)
+	}
+
+	// Launch gamescope + Steam, as the sandbox user and/or inside a network
+	// namespace if configured
+	binary, binArgs := "gamescope", fullArgs
+	if cfg.SandboxEnabled {
+		sandboxUser := cfg.SandboxUser
+		if sandboxUser == "" {
+			sandboxUser = sandbox.DefaultUser
+		}
+		// -p preserves cmd.Env (WAYLAND_DISPLAY, XDG_RUNTIME_DIR,
+		// PULSE_SERVER, ...) into the sandboxed user's session - without it
+		// runuser resets the environment via PAM and gamescope can't find
+		// the Wayland/Pulse sockets sandbox.Prepare just ACL'd for it.
+		binArgs = append([]string{"-p", "-u", sandboxUser, "--", binary}, binArgs...)
+		binary = "runuser"
+		log.Infof("Running sandboxed as user %s", sandboxUser)
+	}
+	if !cfg.EnableNetwork {
+		binArgs = append([]string{"-n", "--", binary}, binArgs...)
+		binary = "unshare"
+		log.Info("Network disabled, running in an unshare -n namespace")
+	}
+
+	cmd := exec.Command(binary, binArgs...)
 	cmd.Env = env
 	cmd.Stdout = logFile
 	cmd.Stderr = logFile
-	
-	err := cmd.Run()
-	
+
+	if err = cmd.Start(); err == nil {
+		stateSession := &state.Session{
+			PID:         cmd.Process.Pid,
+			SessionID:   session.ID,
+			StartTime:   session.StartTime,
+			Enablements: cfg.Enablements(),
+			LogPath:     logPath,
+		}
+		if sb != nil {
+			stateSession.SandboxUser = sb.User
+			stateSession.ACLPaths = sb.Paths()
+		}
+		if dbusProxy != nil {
+			stateSession.DBusProxyPID = dbusProxy.PID()
+		}
+		if cfg.HooksEnabled {
+			stateSession.PostLaunchHooks = cfg.Hooks.PostLaunch
+		}
+		if regErr := state.Register(stateSession); regErr != nil {
+			log.Warnf("Could not register session state: %v", regErr)
+		}
+
+		err = cmd.Wait()
+		state.Remove(stateSession.PID)
+	}
+	cancelGPUSampler()
+	if cancelStreamingWatch != nil {
+		cancelStreamingWatch()
+	}
+	if configWatcher != nil {
+		configWatcher.Stop()
+	}
+
+	// Run post-launch hooks (always) and on-crash hooks (non-zero exit only)
+	if cfg.HooksEnabled {
+		if errs := hooks.RunPostLaunch(cfg); len(errs) > 0 {
+			log.Warnf("%d post_launch hook(s) failed", len(errs))
+		}
+		if err != nil {
+			if errs := hooks.RunOnCrash(cfg); len(errs) > 0 {
+				log.Warnf("%d on_crash hook(s) failed", len(errs))
+			}
+		}
+	}
+
 	// Session ended
 	endTime := time.Now()
 	duration := endTime.Sub(session.StartTime)
-	
+	gpuReduction := gpuSeries.Reduce()
+
 	// Log session end
 	if logFile != nil {
 		fmt.Fprintf(logFile, "\n═══════════════════════════════════════════════════════════════\n")
@@ -176,36 +375,77 @@ func Launch(cfg *config.Config) error {
 		if err != nil {
 			fmt.Fprintf(logFile, "  Exit Error: %v\n", err)
 		}
+		if gpuReduction.Samples > 0 {
+			fmt.Fprintf(logFile, "  GPU Util: avg=%.0f%% max=%.0f%% (p95=%.0f%%) over %d samples\n",
+				gpuReduction.UtilGPU.Avg, gpuReduction.UtilGPU.Max, gpuReduction.UtilGPU.P95, gpuReduction.Samples)
+			fmt.Fprintf(logFile, "  GPU Temp: avg=%.0fC max=%.0fC\n", gpuReduction.TempC.Avg, gpuReduction.TempC.Max)
+		}
 		fmt.Fprintf(logFile, "═══════════════════════════════════════════════════════════════\n")
 		logFile.Close()
 	}
-	
+
 	log.Infof("Gaming session ended after %s", duration.Round(time.Second))
-	
+
 	// Record exit telemetry
 	telemetrySession.EndTime = endTime
 	telemetrySession.Duration = duration
 	if err != nil {
 		telemetrySession.ExitReason = err.Error()
 		telemetrySession.ExitCode = 1
+		if streamingMonitor != nil && streamingMonitor.Connected() {
+			telemetrySession.ExitReason = "streaming-crash"
+		}
+	}
+	if gpuReduction.Samples > 0 {
+		telemetrySession.GPU = &gpuReduction
+	}
+	if hudCSVPath != "" {
+		if stats, statErr := mangohud.ParseCSV(hudCSVPath); statErr == nil {
+			telemetrySession.FPS = &telemetry.FPSSummary{
+				Frames:   stats.Frames,
+				AvgFPS:   stats.AvgFPS,
+				Low1FPS:  stats.Low1FPS,
+				Low01FPS: stats.Low01FPS,
+			}
+		}
 	}
 	telemetry.RecordExit(telemetrySession)
-	
+
 	// Cleanup
 	log.Debug("Performing cleanup")
-	
+
+	if hudSession != nil {
+		hudSession.Cleanup()
+	}
+
+	if sb != nil {
+		sb.Cleanup()
+	}
+
+	if dbusProxy != nil {
+		dbusProxy.Stop()
+	}
+
 	// Stop GameMode
 	if session.GameModeActive {
 		stopGameMode()
 		log.Debug("GameMode deactivated")
 	}
-	
-	switchWorkspace(originalWorkspace)
-	if hypridleWasRunning {
-		startHypridle()
-		log.Debug("Restarted hypridle")
+
+	comp.Switch(originalWorkspace)
+	if idleWasRunning {
+		idle.Start()
+		log.Debug("Restarted idle inhibitor")
 	}
-	
+
+	for _, m := range otherMonitors {
+		if err := comp.EnableOutput(m.Name); err != nil {
+			log.Debugf("Could not re-enable output %s: %v", m.Name, err)
+		} else {
+			log.Infof("Re-enabled output %s", m.Name)
+		}
+	}
+
 	return err
 }
 
@@ -216,17 +456,14 @@ func generateSessionID() string {
 	return hex.EncodeToString(hash[:8])
 }
 
-func checkRequirements() error {
+func checkRequirements() (compositor.Compositor, error) {
 	if _, err := exec.LookPath("steam"); err != nil {
-		return fmt.Errorf("steam not installed")
+		return nil, fmt.Errorf("steam not installed")
 	}
 	if _, err := exec.LookPath("gamescope"); err != nil {
-		return fmt.Errorf("gamescope not installed")
-	}
-	if _, err := exec.LookPath("hyprctl"); err != nil {
-		return fmt.Errorf("hyprctl not found")
+		return nil, fmt.Errorf("gamescope not installed")
 	}
-	return nil
+	return compositor.Detect(), nil
 }
 
 func detectGPU() GPUInfo {
@@ -270,37 +507,106 @@ func detectGPU() GPUInfo {
 	return info
 }
 
-func getResolution(cfg *config.Config) (int, int) {
-	if cfg.Resolution != "auto" {
-		parts := strings.Split(cfg.Resolution, "x")
-		if len(parts) == 2 {
-			w, _ := strconv.Atoi(parts[0])
-			h, _ := strconv.Atoi(parts[1])
-			if w > 0 && h > 0 {
-				return w, h
+// selectMonitor picks which output gamescope binds to and returns it (with
+// any cfg.Resolution/RefreshRate overrides applied) alongside every other
+// connected monitor, so the caller can disable them for the session.
+func selectMonitor(cfg *config.Config, comp compositor.Compositor) (compositor.Monitor, []compositor.Monitor) {
+	monitors, err := comp.Monitors()
+	if err != nil || len(monitors) == 0 {
+		selected := compositor.Monitor{Width: 2560, Height: 1440}
+		if w, h, ok := parseResolution(cfg.Resolution); ok {
+			selected.Width, selected.Height = w, h
+		}
+		return selected, nil
+	}
+
+	idx := pickMonitorIndex(cfg.Output, monitors)
+	selected := monitors[idx]
+
+	if w, h, ok := parseResolution(cfg.Resolution); ok {
+		selected.Width, selected.Height = w, h
+	}
+	if cfg.RefreshRate > 0 {
+		selected.RefreshHz = float64(cfg.RefreshRate)
+	}
+
+	others := make([]compositor.Monitor, 0, len(monitors)-1)
+	for i, m := range monitors {
+		if i != idx {
+			others = append(others, m)
+		}
+	}
+
+	return selected, others
+}
+
+// pickMonitorIndex resolves cfg.Output ("auto"/"primary"/"largest" or a
+// monitor name) to an index into monitors, defaulting to the first monitor.
+func pickMonitorIndex(output string, monitors []compositor.Monitor) int {
+	switch output {
+	case "", "auto", "primary":
+		return 0
+	case "largest":
+		best, bestArea := 0, 0
+		for i, m := range monitors {
+			if area := m.Width * m.Height; area > bestArea {
+				best, bestArea = i, area
+			}
+		}
+		return best
+	default:
+		for i, m := range monitors {
+			if m.Name == output {
+				return i
 			}
 		}
+		return 0
 	}
-	
-	// Auto-detect from Hyprland
-	out, err := exec.Command("hyprctl", "monitors", "-j").Output()
-	if err != nil {
-		return 2560, 1440 // Default fallback
+}
+
+func parseResolution(res string) (int, int, bool) {
+	if res == "auto" || res == "" {
+		return 0, 0, false
 	}
-	
-	var monitors []struct {
-		Width  int `json:"width"`
-		Height int `json:"height"`
+	parts := strings.Split(res, "x")
+	if len(parts) != 2 {
+		return 0, 0, false
 	}
-	
-	if err := json.Unmarshal(out, &monitors); err != nil || len(monitors) == 0 {
-		return 2560, 1440
+	w, errW := strconv.Atoi(parts[0])
+	h, errH := strconv.Atoi(parts[1])
+	if errW != nil || errH != nil || w <= 0 || h <= 0 {
+		return 0, 0, false
 	}
-	
-	return monitors[0].Width, monitors[0].Height
+	return w, h, true
+}
+
+// applyGameOverride returns cfg unchanged if appID is empty or has no
+// matching [games.<appid>] section, otherwise a shallow copy with FSR
+// and/or FrameLimit replaced by the override's values.
+func applyGameOverride(cfg *config.Config, appID string) *config.Config {
+	if appID == "" {
+		return cfg
+	}
+	override, ok := cfg.Games[appID]
+	if !ok {
+		return cfg
+	}
+
+	overridden := *cfg
+	if override.FSR != nil {
+		overridden.FSR = *override.FSR
+	}
+	if override.FrameLimit != nil {
+		overridden.FrameLimit = *override.FrameLimit
+	}
+	log.Infof("Applying per-game override for AppID %s", appID)
+	return &overridden
 }
 
-func buildGamescopeArgs(cfg *config.Config, gpu GPUInfo, width, height int) []string {
+func buildGamescopeArgs(cfg *config.Config, gpu GPUInfo, monitor compositor.Monitor) []string {
+	caps := gamescopeCapabilities()
+	width, height := monitor.Width, monitor.Height
+
 	args := []string{
 		"-W", strconv.Itoa(width),
 		"-H", strconv.Itoa(height),
@@ -310,9 +616,25 @@ func buildGamescopeArgs(cfg *config.Config, gpu GPUInfo, width, height int) []st
 		"--force-windows-fullscreen",
 		"--disable-color-management",
 	}
-	
-	// FSR scaling
-	if cfg.FSR != "off" {
+
+	if monitor.Name != "" {
+		args = append(args, "-O", monitor.Name)
+	}
+	if monitor.RefreshHz > 0 {
+		args = append(args, "-r", strconv.Itoa(int(math.Round(monitor.RefreshHz))))
+	}
+
+	// Scaler selection. "fsr" keeps the legacy inner-resolution path; every
+	// other scaler runs at native resolution and lets gamescope's filter do the work.
+	scaler := cfg.Scaler
+	if scaler == "" || scaler == "auto" {
+		scaler = "fsr"
+		if cfg.FSR == "off" {
+			scaler = "linear"
+		}
+	}
+
+	if scaler == "fsr" && cfg.FSR != "off" {
 		scales := config.FSRScales()
 		if scale, ok := scales[cfg.FSR]; ok {
 			innerW := int(math.Round(float64(width) * scale))
@@ -324,26 +646,103 @@ func buildGamescopeArgs(cfg *config.Config, gpu GPUInfo, width, height int) []st
 		}
 	} else {
 		args = append(args, "-w", strconv.Itoa(width), "-h", strconv.Itoa(height))
+		switch scaler {
+		case "nis":
+			if caps["nis-upscaling"] {
+				args = append(args, "--nis-upscaling")
+			}
+		case "integer":
+			if caps["integer-scale"] {
+				args = append(args, "--integer-scale")
+			}
+		case "nearest":
+			if caps["nearest-neighbor-filter"] {
+				args = append(args, "--nearest-neighbor-filter")
+			}
+		}
 	}
-	
+
+	if cfg.Sharpness > 0 && (scaler == "fsr" || scaler == "nis") {
+		if caps["sharpness"] {
+			args = append(args, "--sharpness", strconv.Itoa(cfg.Sharpness))
+		} else if caps["fsr-sharpness"] {
+			args = append(args, "--fsr-sharpness", strconv.Itoa(cfg.Sharpness))
+		}
+	}
+
+	if cfg.MaxScale > 0 && caps["max-scale"] {
+		args = append(args, "--max-scale", strconv.FormatFloat(cfg.MaxScale, 'g', -1, 64))
+	}
+
+	// HDR / color management
+	if cfg.HDR && caps["hdr-enabled"] {
+		args = append(args, "--hdr-enabled")
+		if cfg.HDRITM && caps["hdr-itm-enable"] {
+			args = append(args, "--hdr-itm-enable")
+			if cfg.HDRPeakNits > 0 && caps["hdr-itm-target-nits"] {
+				args = append(args, "--hdr-itm-target-nits", strconv.Itoa(cfg.HDRPeakNits))
+			}
+		}
+		if cfg.HDRPeakNits > 0 && caps["hdr-sdr-content-nits"] {
+			args = append(args, "--hdr-sdr-content-nits", strconv.Itoa(cfg.HDRPeakNits))
+		}
+	}
+
 	// Frame limit
 	if cfg.FrameLimit > 0 {
 		args = append(args, "--framerate-limit", strconv.Itoa(cfg.FrameLimit))
 	}
-	
+
 	// VRR
 	if cfg.VRR {
 		args = append(args, "--adaptive-sync")
 	}
-	
+
 	// NVIDIA preference
 	if gpu.HasNVIDIA && gpu.NVIDIAVkID != "" {
 		args = append(args, "--prefer-vk-device", gpu.NVIDIAVkID)
 	}
-	
+
+	// Remote Play / Steam Link streaming
+	if cfg.StreamingMode == "host" && caps["pipewire"] {
+		args = append(args, "--pipewire")
+	}
+
 	return args
 }
 
+var (
+	gamescopeCapsOnce sync.Once
+	gamescopeCaps     map[string]bool
+)
+
+// gamescopeCapabilities runs `gamescope --help` once and caches which of the
+// long options we care about are supported, so older gamescope builds without
+// a flag simply don't get it instead of failing to launch on an unknown option.
+func gamescopeCapabilities() map[string]bool {
+	gamescopeCapsOnce.Do(func() {
+		gamescopeCaps = make(map[string]bool)
+		out, err := exec.Command("gamescope", "--help").CombinedOutput()
+		if err != nil {
+			log.Warnf("Failed to probe gamescope capabilities: %v", err)
+			return
+		}
+
+		help := string(out)
+		for _, flag := range []string{
+			"nis-upscaling", "integer-scale", "nearest-neighbor-filter",
+			"sharpness", "fsr-sharpness", "hdr-enabled", "hdr-itm-enable",
+			"hdr-sdr-content-nits", "hdr-itm-target-nits", "max-scale",
+			"force-composition", "rt", "pipewire",
+		} {
+			if strings.Contains(help, "--"+flag) {
+				gamescopeCaps[flag] = true
+			}
+		}
+	})
+	return gamescopeCaps
+}
+
 func setupEnvironment(cfg *config.Config, gpu GPUInfo) []string {
 	env := os.Environ()
 	
@@ -354,6 +753,7 @@ func setupEnvironment(cfg *config.Config, gpu GPUInfo) []string {
 	}
 	
 	env = append(env, "SDL_VIDEO_MINIMIZE_ON_FOCUS_LOSS=0")
+	env = append(env, streamingEnv(cfg.StreamingMode)...)
 	
 	// Steam-specific environment
 	env = append(env, "STEAM_RUNTIME_PREFER_HOST_LIBRARIES=0")
@@ -364,7 +764,7 @@ func setupEnvironment(cfg *config.Config, gpu GPUInfo) []string {
 	env = append(env, "STEAM_GAMESCOPE_HAS_TEARING_SUPPORT=1")
 	env = append(env, "STEAM_GAMESCOPE_TEARING_SUPPORTED=1")
 	env = append(env, "STEAM_GAMESCOPE_VRR_SUPPORTED=1")
-	env = append(env, "STEAM_DISPLAY_REFRESH_LIMITS=60,72,120,144")
+	env = append(env, "STEAM_DISPLAY_REFRESH_LIMITS="+refreshLimitsEnv(cfg.RefreshRates))
 	
 	// Input handling - ensure keyboard/mouse work in gamescope
 	env = append(env, "SDL_GAMECONTROLLERCONFIG=")
@@ -379,10 +779,58 @@ func setupEnvironment(cfg *config.Config, gpu GPUInfo) []string {
 		env = append(env, "__GL_GSYNC_ALLOWED=1")
 		env = append(env, "__GL_VRR_ALLOWED=1")
 	}
-	
+
+	return applyEnablements(env, cfg)
+}
+
+// applyEnablements strips or blanks the environment variables gating access
+// to resources the config's enablements bitfield has turned off, so a
+// disabled resource is absent rather than merely unused.
+func applyEnablements(env []string, cfg *config.Config) []string {
+	if !cfg.EnableWayland {
+		env = removeEnvVar(env, "WAYLAND_DISPLAY")
+	}
+	if !cfg.EnableX11 {
+		env = removeEnvVar(env, "DISPLAY")
+	}
+	if !cfg.EnablePulse {
+		env = removeEnvVar(env, "PULSE_SERVER")
+	}
+	if !cfg.EnableDBus {
+		env = setEnvVar(env, "DBUS_SESSION_BUS_ADDRESS", "")
+	}
 	return env
 }
 
+func removeEnvVar(env []string, key string) []string {
+	prefix := key + "="
+	out := env[:0]
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, prefix) {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
+func setEnvVar(env []string, key, value string) []string {
+	env = removeEnvVar(env, key)
+	return append(env, key+"="+value)
+}
+
+// refreshLimitsEnv renders the refresh rates Steam should offer in its in-game
+// overlay, falling back to a sane default set if none are configured.
+func refreshLimitsEnv(rates []int) string {
+	if len(rates) == 0 {
+		rates = []int{60, 72, 120, 144}
+	}
+	strs := make([]string, len(rates))
+	for i, r := range rates {
+		strs[i] = strconv.Itoa(r)
+	}
+	return strings.Join(strs, ",")
+}
+
 // startGameMode enables GameMode for performance optimization
 func startGameMode() bool {
 	// Check if gamemoded is available
@@ -425,93 +873,21 @@ func killSteam() {
 	time.Sleep(time.Second)
 }
 
-func stopHypridle() bool {
-	out, _ := exec.Command("pgrep", "-x", "hypridle").Output()
-	if len(out) > 0 {
-		exec.Command("pkill", "hypridle").Run()
-		return true
-	}
-	return false
-}
-
-func startHypridle() {
-	cmd := exec.Command("hypridle")
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-	}
-	cmd.Start()
-}
-
-func getCurrentWorkspace() int {
-	out, err := exec.Command("hyprctl", "activeworkspace", "-j").Output()
-	if err != nil {
-		return 1
-	}
-	
-	var workspace struct {
-		ID int `json:"id"`
-	}
-	
-	if err := json.Unmarshal(out, &workspace); err != nil {
-		return 1
-	}
-	
-	return workspace.ID
-}
-
-func findEmptyWorkspace(preferred int) int {
-	out, err := exec.Command("hyprctl", "workspaces", "-j").Output()
-	if err != nil {
-		return preferred
-	}
-	
-	var workspaces []struct {
-		ID int `json:"id"`
-	}
-	
-	if err := json.Unmarshal(out, &workspaces); err != nil {
-		return preferred
-	}
-	
-	usedWorkspaces := make(map[int]bool)
-	for _, ws := range workspaces {
-		usedWorkspaces[ws.ID] = true
-	}
-	
-	// Check if preferred is empty
-	if !usedWorkspaces[preferred] {
-		return preferred
-	}
-	
-	// Find first empty between 1-10
-	for i := 1; i <= 10; i++ {
-		if !usedWorkspaces[i] {
-			return i
-		}
-	}
-	
-	return preferred
-}
-
-func switchWorkspace(ws int) {
-	exec.Command("hyprctl", "dispatch", "workspace", strconv.Itoa(ws)).Run()
-}
-
-func createLogFile(sessionID string) *os.File {
+func createLogFile(sessionID string) (*os.File, string) {
 	home, _ := os.UserHomeDir()
 	stateDir := filepath.Join(home, ".cache", "wizado", "sessions")
 	os.MkdirAll(stateDir, 0755)
-	
+
 	// Use session ID in filename for easy identification
 	logPath := filepath.Join(stateDir, fmt.Sprintf("session_%s.log", sessionID))
 	file, _ := os.Create(logPath)
-	
+
 	// Also create/update a symlink to the latest session
 	latestLink := filepath.Join(home, ".cache", "wizado", "latest-session.log")
 	os.Remove(latestLink)
 	os.Symlink(logPath, latestLink)
-	
-	return file
+
+	return file, logPath
 }
 
 // CollectSystemInfo gathers and records system information before launch
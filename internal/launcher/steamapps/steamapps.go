@@ -0,0 +1,212 @@
+// Package steamapps discovers Steam's installed app library by reading
+// libraryfolders.vdf and each library's appmanifest_*.acf files directly -
+// the same flat "Valve Data Format" Steam itself writes, parsed here
+// rather than linking a VDF library.
+package steamapps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Game is one Steam title found in a library folder.
+type Game struct {
+	AppID      string
+	Name       string
+	InstallDir string
+}
+
+// Discover finds every Steam library folder reachable from the default
+// install path and returns every installed app across all of them, sorted
+// by name. A missing or unparsable libraryfolders.vdf falls back to just
+// the default library - wizado should still work before Steam has ever
+// registered an extra library folder.
+func Discover() ([]Game, error) {
+	libraries, err := libraryPaths()
+	if err != nil || len(libraries) == 0 {
+		libraries = []string{defaultSteamDir()}
+	}
+
+	var games []Game
+	seen := make(map[string]bool)
+	for _, lib := range libraries {
+		manifests, err := filepath.Glob(filepath.Join(lib, "steamapps", "appmanifest_*.acf"))
+		if err != nil {
+			continue
+		}
+		for _, path := range manifests {
+			game, err := parseManifest(path)
+			if err != nil || game.AppID == "" || seen[game.AppID] {
+				continue
+			}
+			seen[game.AppID] = true
+			games = append(games, game)
+		}
+	}
+
+	sort.Slice(games, func(i, j int) bool { return games[i].Name < games[j].Name })
+	return games, nil
+}
+
+// defaultSteamDir returns Steam's default install location under the
+// user's home directory.
+func defaultSteamDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "Steam")
+}
+
+// libraryPaths reads libraryfolders.vdf for every registered library
+// folder's path, starting from the default Steam install location.
+func libraryPaths() ([]string, error) {
+	path := filepath.Join(defaultSteamDir(), "steamapps", "libraryfolders.vdf")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, root, err := parseVDF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, v := range root {
+		entry, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if p, ok := entry["path"].(string); ok && p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// parseManifest reads one appmanifest_*.acf file for its appid, name, and
+// installdir.
+func parseManifest(path string) (Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Game{}, err
+	}
+
+	_, state, err := parseVDF(data)
+	if err != nil {
+		return Game{}, err
+	}
+
+	var game Game
+	if v, ok := state["appid"].(string); ok {
+		game.AppID = v
+	}
+	if v, ok := state["name"].(string); ok {
+		game.Name = v
+	}
+	if v, ok := state["installdir"].(string); ok {
+		game.InstallDir = v
+	}
+	return game, nil
+}
+
+// parseVDF parses a VDF document: a single root "key" { ... } block of
+// nested quoted key/value pairs, the format Steam uses for both
+// libraryfolders.vdf and appmanifest_*.acf. A value is either a string or
+// a nested object (map[string]interface{}).
+func parseVDF(data []byte) (string, map[string]interface{}, error) {
+	tokens, err := tokenizeVDF(data)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(tokens) < 2 {
+		return "", nil, fmt.Errorf("steamapps: empty VDF document")
+	}
+	if tokens[1] != "{" {
+		return "", nil, fmt.Errorf("steamapps: expected %q after root key %q", "{", tokens[0])
+	}
+
+	root, _, err := parseVDFObject(tokens, 2)
+	if err != nil {
+		return "", nil, err
+	}
+	return tokens[0], root, nil
+}
+
+// parseVDFObject parses tokens starting at index i (just past an opening
+// "{") until its matching "}", returning the resulting object and the
+// index just past that "}".
+func parseVDFObject(tokens []string, i int) (map[string]interface{}, int, error) {
+	obj := make(map[string]interface{})
+	for i < len(tokens) {
+		if tokens[i] == "}" {
+			return obj, i + 1, nil
+		}
+
+		key := tokens[i]
+		i++
+		if i >= len(tokens) {
+			return nil, i, fmt.Errorf("steamapps: truncated VDF document after key %q", key)
+		}
+
+		if tokens[i] == "{" {
+			child, next, err := parseVDFObject(tokens, i+1)
+			if err != nil {
+				return nil, next, err
+			}
+			obj[key] = child
+			i = next
+		} else {
+			obj[key] = tokens[i]
+			i++
+		}
+	}
+	return nil, i, fmt.Errorf("steamapps: unterminated VDF object")
+}
+
+// tokenizeVDF splits a VDF document into quoted-string and brace tokens,
+// skipping whitespace and "//" line comments.
+func tokenizeVDF(data []byte) ([]string, error) {
+	var tokens []string
+	i := 0
+	for i < len(data) {
+		c := data[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case c == '{' || c == '}':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			var sb strings.Builder
+			for j < len(data) && data[j] != '"' {
+				if data[j] == '\\' && j+1 < len(data) {
+					sb.WriteByte(data[j+1])
+					j += 2
+					continue
+				}
+				sb.WriteByte(data[j])
+				j++
+			}
+			if j >= len(data) {
+				return nil, fmt.Errorf("steamapps: unterminated quoted string in VDF document")
+			}
+			tokens = append(tokens, sb.String())
+			i = j + 1
+		default:
+			j := i
+			for j < len(data) && data[j] != ' ' && data[j] != '\t' && data[j] != '\r' && data[j] != '\n' && data[j] != '{' && data[j] != '}' {
+				j++
+			}
+			tokens = append(tokens, string(data[i:j]))
+			i = j
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,105 @@
+package launcher
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const streamingPollInterval = 2 * time.Second
+
+// StreamingMonitor tracks whether a Steam Remote Play / Steam Link client is
+// currently connected, by tailing Steam's streaming log for connect/disconnect
+// markers. Gamescope is known to crash in some configurations when a Remote
+// Play client connects mid-session, so this lets Launch tag that failure mode
+// distinctly in telemetry instead of recording it as a generic crash.
+type StreamingMonitor struct {
+	mu        sync.Mutex
+	connected bool
+}
+
+// Connected reports whether a streaming client was connected as of the last log read.
+func (m *StreamingMonitor) Connected() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.connected
+}
+
+func (m *StreamingMonitor) setConnected(v bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connected = v
+}
+
+// watchStreamingLog polls ~/.steam/steam/logs/streaming_log.txt for
+// RemoteClientConnected/RemoteClientDisconnected markers until ctx is cancelled.
+func watchStreamingLog(ctx context.Context, monitor *StreamingMonitor) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	logPath := filepath.Join(home, ".steam", "steam", "logs", "streaming_log.txt")
+
+	ticker := time.NewTicker(streamingPollInterval)
+	defer ticker.Stop()
+
+	var offset int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			offset = scanStreamingLog(logPath, offset, monitor)
+		}
+	}
+}
+
+// scanStreamingLog reads any lines appended to logPath since offset and
+// returns the new offset. A missing or rotated log is handled silently.
+func scanStreamingLog(logPath string, offset int64, monitor *StreamingMonitor) int64 {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return offset
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return offset
+	}
+	if info.Size() < offset {
+		offset = 0 // log was rotated or truncated
+	}
+	if _, err := f.Seek(offset, 0); err != nil {
+		return offset
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, "RemoteClientConnected"):
+			monitor.setConnected(true)
+		case strings.Contains(line, "RemoteClientDisconnected"):
+			monitor.setConnected(false)
+		}
+	}
+
+	return info.Size()
+}
+
+// streamingEnv returns the environment variables that make a "host" streaming
+// session reliable from first launch instead of only after a reconnect.
+func streamingEnv(streamingMode string) []string {
+	if streamingMode != "host" {
+		return nil
+	}
+	return []string{
+		"STEAM_GAMESCOPE_STREAMING_CLIENT=1",
+		"STEAM_GAMESCOPE_COLOR_MANAGED_STREAMING=1",
+	}
+}
@@ -2,7 +2,9 @@ package license
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,6 +21,13 @@ type verifyRequest struct {
 type verifyResponse struct {
 	Valid bool   `json:"valid"`
 	Error string `json:"error,omitempty"`
+
+	// ExpiresAt and Signature let this response be cached and trusted
+	// offline later: Signature is an Ed25519 signature (base64) over
+	// {email, license, valid, expiresAt}, checked against the pinned key in
+	// cache.go before a cached decision is ever used in place of the network.
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	Signature string    `json:"signature,omitempty"`
 }
 
 type activateRequest struct {
@@ -28,104 +37,112 @@ type activateRequest struct {
 }
 
 type activateResponse struct {
-	Activated  bool   `json:"activated"`
-	Email      string `json:"email,omitempty"`
-	SlotsUsed  int    `json:"slotsUsed,omitempty"`
-	SlotsTotal int    `json:"slotsTotal,omitempty"`
-	Message    string `json:"message,omitempty"`
-	Error      string `json:"error,omitempty"`
+	Activated   bool   `json:"activated"`
+	Email       string `json:"email,omitempty"`
+	SlotsUsed   int    `json:"slotsUsed,omitempty"`
+	SlotsTotal  int    `json:"slotsTotal,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Error       string `json:"error,omitempty"`
+	LicenseBlob string `json:"licenseBlob,omitempty"` // base64 payload+RSA signature, persisted verbatim
 }
 
-// VerifyAPI calls the license verification API
-func VerifyAPI(email, licenseKey string) (bool, error) {
-	client := &http.Client{Timeout: apiTimeout}
-	
+// VerifyAPI calls the license verification API, retrying transient failures
+// with backoff through doWithRetry. If the API can't be reached at all
+// (network error, rate limited, or the circuit breaker is open), it falls
+// back to the last signed verify response cached for this exact
+// (email, licenseKey) pair, as long as that cache is still within its grace
+// window - see cache.go.
+func VerifyAPI(ctx context.Context, email, licenseKey string) (bool, error) {
 	reqBody := verifyRequest{
 		Email:   email,
 		License: licenseKey,
 	}
-	
+
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		return false, err
 	}
-	
-	req, err := http.NewRequest("POST", apiURL+"/license/verify", bytes.NewBuffer(jsonData))
+
+	resp, err := doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, apiURL+"/license/verify", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
+		if cached, cacheErr := loadVerifyCache(email, licenseKey); cacheErr == nil {
+			return cached.Valid, nil
+		} else if errors.Is(cacheErr, ErrGracePeriodExpired) {
+			return false, ErrGracePeriodExpired
+		}
 		return false, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	
-	resp, err := client.Do(req)
-	if err != nil {
-		return false, ErrNetworkError
-	}
 	defer resp.Body.Close()
-	
-	if resp.StatusCode >= 500 {
-		return false, ErrNetworkError
-	}
-	
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return false, err
 	}
-	
+
 	var result verifyResponse
 	if err := json.Unmarshal(body, &result); err != nil {
 		return false, err
 	}
-	
+
+	saveVerifyCache(email, licenseKey, result)
+
 	return result.Valid, nil
 }
 
 // ActivateAPI calls the license activation API
-func ActivateAPI(email, licenseKey, machineID string) (*ActivationResult, error) {
-	client := &http.Client{Timeout: apiTimeout * 2}
-	
+func ActivateAPI(ctx context.Context, email, licenseKey, machineID string) (*ActivationResult, error) {
 	reqBody := activateRequest{
 		Email:     email,
 		License:   licenseKey,
 		MachineID: machineID,
 	}
-	
+
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
-	
-	req, err := http.NewRequest("POST", apiURL+"/license/activate", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	
-	resp, err := client.Do(req)
+
+	resp, err := doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, apiURL+"/license/activate", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
 		return &ActivationResult{
 			Success: false,
 			Message: fmt.Sprintf("Network error: %v", err),
-		}, ErrNetworkError
+		}, err
 	}
 	defer resp.Body.Close()
-	
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	var apiResp activateResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		return nil, err
 	}
-	
+
 	result := &ActivationResult{
-		Success:    apiResp.Activated,
-		Email:      apiResp.Email,
-		SlotsUsed:  apiResp.SlotsUsed,
-		SlotsTotal: apiResp.SlotsTotal,
+		Success:     apiResp.Activated,
+		Email:       apiResp.Email,
+		SlotsUsed:   apiResp.SlotsUsed,
+		SlotsTotal:  apiResp.SlotsTotal,
+		LicenseBlob: apiResp.LicenseBlob,
 	}
-	
+
 	if apiResp.Message != "" {
 		result.Message = apiResp.Message
 	} else if apiResp.Error != "" {
@@ -133,44 +150,42 @@ func ActivateAPI(email, licenseKey, machineID string) (*ActivationResult, error)
 	} else if !apiResp.Activated {
 		result.Message = "Activation failed"
 	}
-	
+
 	return result, nil
 }
 
 // RecoverAPI retrieves a license by email
-func RecoverAPI(email string) (string, error) {
-	client := &http.Client{Timeout: apiTimeout}
-	
+func RecoverAPI(ctx context.Context, email string) (string, error) {
 	reqBody := map[string]string{"email": email}
 	jsonData, _ := json.Marshal(reqBody)
-	
-	req, err := http.NewRequest("POST", apiURL+"/license/recover", bytes.NewBuffer(jsonData))
+
+	resp, err := doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, apiURL+"/license/recover", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", ErrNetworkError
-	}
 	defer resp.Body.Close()
-	
+
 	body, _ := io.ReadAll(resp.Body)
-	
+
 	var result map[string]interface{}
 	if err := json.Unmarshal(body, &result); err != nil {
 		return "", err
 	}
-	
+
 	if license, ok := result["license"].(string); ok {
 		return license, nil
 	}
-	
+
 	if errMsg, ok := result["error"].(string); ok {
 		return "", fmt.Errorf("%s", errMsg)
 	}
-	
+
 	return "", fmt.Errorf("license not found")
 }
-
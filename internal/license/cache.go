@@ -0,0 +1,133 @@
+package license
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// verifyCachePublicKeyB64 is wattfource's Ed25519 public key, pinned at
+// compile time, used only to authenticate the verify API's signed response
+// before it's trusted offline. Distinct from licensePublicKeyPEM in rsa.go:
+// that key signs license blobs issued once at purchase time, this one signs
+// a live verify decision that's re-issued on every check-in.
+const verifyCachePublicKeyB64 = "TU0en52hWzLPMOQQYA0n9gFtQZ2qdVGINT12sPqkO6A="
+
+// verifyCacheGraceDays bounds how long a cached verify decision is trusted
+// once the network is unreachable, independent of the signed ExpiresAt the
+// server issued it with.
+const verifyCacheGraceDays = 7
+
+func verifyCachePublicKey() ed25519.PublicKey {
+	key, err := base64.StdEncoding.DecodeString(verifyCachePublicKeyB64)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil
+	}
+	return ed25519.PublicKey(key)
+}
+
+// verifyCachePath returns the path of the cached verify response, kept
+// under ~/.cache (unlike the license blob itself, which lives under
+// ~/.config) since it's a disposable, re-derivable check-in record rather
+// than user configuration.
+func verifyCachePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cache", "wizado", "license.cache")
+}
+
+// verifyCacheEntry is what's persisted to disk: the signed server response
+// plus the request it answered (so a cache written for one license isn't
+// served back for a different one) and when it was cached (for the grace
+// window, which is ours to enforce, not the server's).
+type verifyCacheEntry struct {
+	Email    string         `json:"email"`
+	License  string         `json:"license"`
+	Response verifyResponse `json:"response"`
+	CachedAt time.Time      `json:"cachedAt"`
+}
+
+// canonicalVerifyPayload reproduces the bytes the server signed, so the
+// signature can be checked both right after the API call and again when the
+// cached entry is later loaded from disk.
+func canonicalVerifyPayload(email, licenseKey string, valid bool, expiresAt time.Time) []byte {
+	data, _ := json.Marshal(struct {
+		Email     string    `json:"email"`
+		License   string    `json:"license"`
+		Valid     bool      `json:"valid"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}{email, licenseKey, valid, expiresAt})
+	return data
+}
+
+// verifyResponseSignature reports whether resp's signature validates for
+// (email, licenseKey) against the pinned public key.
+func verifyResponseSignature(email, licenseKey string, resp verifyResponse) bool {
+	pub := verifyCachePublicKey()
+	if pub == nil || resp.Signature == "" {
+		return false
+	}
+	sig, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return false
+	}
+	payload := canonicalVerifyPayload(email, licenseKey, resp.Valid, resp.ExpiresAt)
+	return ed25519.Verify(pub, payload, sig)
+}
+
+// saveVerifyCache persists resp for later offline use. Responses without a
+// signature (e.g. from a server that hasn't rolled out signing yet) are not
+// cached, since an unsigned entry could never be trusted back offline.
+func saveVerifyCache(email, licenseKey string, resp verifyResponse) {
+	if resp.Signature == "" {
+		return
+	}
+
+	entry := verifyCacheEntry{
+		Email:    email,
+		License:  licenseKey,
+		Response: resp,
+		CachedAt: time.Now().UTC(),
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	dir := filepath.Dir(verifyCachePath())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+	os.WriteFile(verifyCachePath(), data, 0600)
+}
+
+// loadVerifyCache returns the last cached verify decision for (email,
+// licenseKey), if one exists, its signature validates, and it's still
+// within the grace window. ErrGracePeriodExpired is returned specifically
+// so callers can distinguish "no usable cache" from "there was a cache, but
+// it's too old to trust" for the TUI to render differently.
+func loadVerifyCache(email, licenseKey string) (*verifyResponse, error) {
+	data, err := os.ReadFile(verifyCachePath())
+	if err != nil {
+		return nil, ErrNetworkError
+	}
+
+	var entry verifyCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, ErrNetworkError
+	}
+
+	if entry.Email != email || entry.License != licenseKey {
+		return nil, ErrNetworkError
+	}
+	if !verifyResponseSignature(email, licenseKey, entry.Response) {
+		return nil, ErrNetworkError
+	}
+	if time.Since(entry.CachedAt) > verifyCacheGraceDays*24*time.Hour {
+		return nil, ErrGracePeriodExpired
+	}
+
+	return &entry.Response, nil
+}
@@ -2,6 +2,7 @@
 package license
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -34,14 +35,48 @@ const (
 	clockDriftTolerance = 5 * time.Minute
 )
 
-// License represents a stored license
+// LicensePayload is the vendor-signed data carried inside a license blob:
+// everything needed to enforce expiry, entitlements, and machine binding
+// without ever contacting the API.
+type LicensePayload struct {
+	Key       string          `json:"license"`
+	Email     string          `json:"email"`
+	MachineID string          `json:"machineId"`
+	IssuedAt  time.Time       `json:"issuedAt"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+	Tier      string          `json:"tier,omitempty"` // e.g. "free", "pro"
+	Features  map[string]bool `json:"features,omitempty"`
+}
+
+// License is a loaded, signature-verified license: the signed payload plus
+// the raw blob it came from (persisted verbatim, never re-signed locally)
+// and local bookkeeping that isn't part of the signed data - it reflects
+// the vendor's live-updating view (seat usage) or our own check-in cadence
+// (re-verification), neither of which belongs baked into a static blob.
 type License struct {
-	Key          string    `json:"license"`
-	Email        string    `json:"email"`
-	MachineID    string    `json:"machineId"`
-	ActivatedAt  time.Time `json:"activatedAt"`
-	LastVerified time.Time `json:"lastVerified"`
-	Signature    string    `json:"signature"`
+	LicensePayload
+	Raw          string
+	LastVerified time.Time
+	SeatsUsed    int
+	SeatsTotal   int
+}
+
+// HasFeature reports whether the checked license grants the named feature.
+// A nil License (no license loaded) never has any feature.
+func (r Result) HasFeature(name string) bool {
+	if r.License == nil {
+		return false
+	}
+	return r.License.Features[name]
+}
+
+// Expired reports whether the checked license's signed ExpiresAt has
+// passed. A nil License or zero ExpiresAt (no expiry set) is never expired.
+func (r Result) Expired() bool {
+	if r.License == nil || r.License.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().After(r.License.ExpiresAt)
 }
 
 // Result holds the result of a license check
@@ -53,11 +88,12 @@ type Result struct {
 
 // ActivationResult holds the result of a license activation
 type ActivationResult struct {
-	Success   bool
-	Email     string
-	SlotsUsed int
-	SlotsTotal int
-	Message   string
+	Success     bool
+	Email       string
+	SlotsUsed   int
+	SlotsTotal  int
+	Message     string
+	LicenseBlob string
 }
 
 var (
@@ -67,21 +103,35 @@ var (
 	ErrClockTampered  = errors.New("system clock manipulation detected")
 	ErrMachineMismatch = errors.New("license activated on different machine")
 	ErrNetworkError   = errors.New("network error")
+
+	// ErrRateLimited is returned when the license API responds 429 on every
+	// retry attempt.
+	ErrRateLimited = errors.New("license API rate limited")
+	// ErrCircuitOpen is returned without making a network call when the
+	// shared circuit breaker has tripped after consecutive failures.
+	ErrCircuitOpen = errors.New("license API circuit breaker open")
+	// ErrGracePeriodExpired is returned when the network is unreachable and
+	// the last cached verify decision is too old to trust.
+	ErrGracePeriodExpired = errors.New("offline grace period expired")
 )
 
 // Paths returns the license directory and file paths
-func Paths() (dir string, file string, timestampFile string) {
+func Paths() (dir string, file string, timestampFile string, localStateFile string) {
 	home, _ := os.UserHomeDir()
 	dir = filepath.Join(home, ".config", "wizado")
-	file = filepath.Join(dir, "license.json")
+	file = filepath.Join(dir, "license.blob")
 	timestampFile = filepath.Join(dir, ".last_known_time")
+	localStateFile = filepath.Join(dir, ".local_state")
 	return
 }
 
-// Load reads the license from disk
+// Load reads the license blob from disk, verifies its RSA signature against
+// the pinned public key, and returns the parsed payload merged with local
+// bookkeeping (re-verification time, seat counts). A blob whose signature
+// doesn't verify is reported as ErrTampered rather than parsed.
 func Load() (*License, error) {
-	_, licenseFile, _ := Paths()
-	
+	_, licenseFile, _, _ := Paths()
+
 	data, err := os.ReadFile(licenseFile)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -89,82 +139,105 @@ func Load() (*License, error) {
 		}
 		return nil, err
 	}
-	
-	var license License
-	if err := json.Unmarshal(data, &license); err != nil {
+
+	payload, err := parseLicenseBlob(string(data))
+	if err != nil {
 		return nil, err
 	}
-	
-	return &license, nil
+
+	state := loadLocalState()
+	lastVerified := time.Time{}
+	if state.LastVerified != 0 {
+		lastVerified = time.Unix(state.LastVerified, 0).UTC()
+	}
+
+	return &License{
+		LicensePayload: *payload,
+		Raw:            string(data),
+		LastVerified:   lastVerified,
+		SeatsUsed:      state.SeatsUsed,
+		SeatsTotal:     state.SeatsTotal,
+	}, nil
 }
 
-// Save writes the license to disk with HMAC signature
-func Save(license *License) error {
-	dir, licenseFile, _ := Paths()
-	
-	// Ensure directory exists
+// Save persists a vendor-issued license blob verbatim. The client never
+// signs a license itself - only the vendor's offline signing tool holds the
+// private key - so there's nothing to compute here.
+func Save(blob string) error {
+	dir, licenseFile, _, _ := Paths()
+
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
-	
-	// Compute signature
-	license.Signature = ComputeSignature(license.Key, license.Email, license.MachineID, license.ActivatedAt)
-	
-	data, err := json.MarshalIndent(license, "", "  ")
-	if err != nil {
-		return err
-	}
-	
-	if err := os.WriteFile(licenseFile, data, 0600); err != nil {
-		return err
-	}
-	
-	// Save timestamp for clock protection
-	saveTimestamp()
-	
-	return nil
+
+	return os.WriteFile(licenseFile, []byte(blob), 0600)
+}
+
+// ValidateBlob verifies a license blob's RSA signature and returns its
+// decoded payload without installing it, so callers like `wizado license
+// import` can reject a bad file before it ever reaches Save.
+func ValidateBlob(blob string) (*LicensePayload, error) {
+	return parseLicenseBlob(blob)
 }
 
 // Clear removes the stored license
 func Clear() error {
-	_, licenseFile, timestampFile := Paths()
+	_, licenseFile, timestampFile, localStateFile := Paths()
 	os.Remove(licenseFile)
 	os.Remove(timestampFile)
+	os.Remove(localStateFile)
 	return nil
 }
 
-// Check validates the stored license
+// Check validates the stored license, only contacting the API if the
+// reverifyDays cadence has elapsed.
 func Check() Result {
+	return check(false)
+}
+
+// Verify forces a re-check against the license API, bypassing the
+// reverifyDays cadence Check() normally respects. Used by headless/scripted
+// flows (the `wizado license verify` CLI command) that want an authoritative
+// answer immediately rather than whatever Check() last cached.
+func Verify() Result {
+	return check(true)
+}
+
+func check(force bool) Result {
 	// Check clock manipulation first
 	if !clockIsValid() {
 		return Result{Status: StatusClockTampered, Error: ErrClockTampered}
 	}
-	
+
 	license, err := Load()
 	if err != nil {
-		if errors.Is(err, ErrNoLicense) {
+		switch {
+		case errors.Is(err, ErrNoLicense):
 			return Result{Status: StatusNoLicense, Error: ErrNoLicense}
+		case errors.Is(err, ErrTampered):
+			Clear()
+			return Result{Status: StatusTampered, Error: ErrTampered}
+		default:
+			return Result{Status: StatusInvalid, Error: err}
 		}
-		return Result{Status: StatusInvalid, Error: err}
 	}
-	
-	// Verify HMAC signature
-	if !VerifySignature(license) {
-		Clear()
-		return Result{Status: StatusTampered, Error: ErrTampered}
+
+	// ExpiresAt is part of the signed payload, so it's enforceable offline
+	if !license.ExpiresAt.IsZero() && time.Now().After(license.ExpiresAt) {
+		return Result{Status: StatusExpired, License: license, Error: ErrInvalidLicense}
 	}
-	
+
 	// Check machine ID
 	currentMachineID := GenerateMachineID()
 	if license.MachineID != currentMachineID {
 		return Result{Status: StatusMachineMismatch, License: license, Error: ErrMachineMismatch}
 	}
-	
+
 	// Check if re-verification is needed
-	if needsReverification(license) {
-		result, err := VerifyAPI(license.Email, license.Key)
+	if force || needsReverification(license) {
+		result, err := VerifyAPI(context.Background(), license.Email, license.Key)
 		if err != nil {
-			// Network error - check grace period
+			// Network error (or rate limited/circuit open) - check grace period
 			if withinGracePeriod(license) {
 				return Result{Status: StatusOfflineGrace, License: license}
 			}
@@ -175,10 +248,14 @@ func Check() Result {
 			Clear()
 			return Result{Status: StatusInvalid, Error: ErrInvalidLicense}
 		}
-		
-		// Update timestamp
+
+		// Update re-verification bookkeeping; the blob itself doesn't change
 		license.LastVerified = time.Now().UTC()
-		Save(license)
+		saveLocalState(localStateData{
+			LastVerified: license.LastVerified.Unix(),
+			SeatsUsed:    license.SeatsUsed,
+			SeatsTotal:   license.SeatsTotal,
+		})
 		return Result{Status: StatusValid, License: license}
 	}
 	
@@ -194,7 +271,7 @@ func Check() Result {
 func Activate(email, key string) (*ActivationResult, error) {
 	machineID := GenerateMachineID()
 	
-	result, err := ActivateAPI(email, key, machineID)
+	result, err := ActivateAPI(context.Background(), email, key, machineID)
 	if err != nil {
 		return nil, err
 	}
@@ -203,20 +280,17 @@ func Activate(email, key string) (*ActivationResult, error) {
 		return result, errors.New(result.Message)
 	}
 	
-	// Save the license
-	now := time.Now().UTC()
-	license := &License{
-		Key:          key,
-		Email:        email,
-		MachineID:    machineID,
-		ActivatedAt:  now,
-		LastVerified: now,
-	}
-	
-	if err := Save(license); err != nil {
+	// Persist the vendor-signed blob verbatim and start the re-verification
+	// clock fresh, recording the seat usage the activation response reported
+	if err := Save(result.LicenseBlob); err != nil {
 		return nil, err
 	}
-	
+	saveLocalState(localStateData{
+		LastVerified: time.Now().UTC().Unix(),
+		SeatsUsed:    result.SlotsUsed,
+		SeatsTotal:   result.SlotsTotal,
+	})
+
 	return result, nil
 }
 
@@ -234,29 +308,68 @@ func withinGracePeriod(license *License) bool {
 
 // clockIsValid checks for clock manipulation
 func clockIsValid() bool {
-	_, _, timestampFile := Paths()
-	
+	_, _, timestampFile, _ := Paths()
+
 	data, err := os.ReadFile(timestampFile)
 	if err != nil {
 		return true // No reference timestamp, assume valid
 	}
-	
+
 	var lastKnown int64
 	if _, err := fmt.Sscanf(string(data), "%d", &lastKnown); err != nil {
 		return true
 	}
-	
+
 	now := time.Now().Unix()
 	drift := time.Duration(lastKnown-now) * time.Second
-	
+
 	return drift <= clockDriftTolerance
 }
 
 // saveTimestamp saves the current time for clock protection
 func saveTimestamp() {
-	dir, _, timestampFile := Paths()
+	dir, _, timestampFile, _ := Paths()
 	os.MkdirAll(dir, 0700)
 	now := time.Now().Unix()
 	os.WriteFile(timestampFile, []byte(fmt.Sprintf("%d", now)), 0600)
 }
 
+// localStateData is bookkeeping that lives alongside the signed license
+// blob but isn't part of it: re-verification cadence and the vendor's live
+// seat count, both of which can change without the blob being reissued.
+type localStateData struct {
+	LastVerified int64 `json:"lastVerified"`
+	SeatsUsed    int   `json:"seatsUsed"`
+	SeatsTotal   int   `json:"seatsTotal"`
+}
+
+// loadLocalState reads local bookkeeping, returning a zero value if none has
+// been recorded yet (e.g. a blob installed by hand rather than Activate).
+func loadLocalState() localStateData {
+	_, _, _, localStateFile := Paths()
+
+	data, err := os.ReadFile(localStateFile)
+	if err != nil {
+		return localStateData{}
+	}
+
+	var state localStateData
+	if err := json.Unmarshal(data, &state); err != nil {
+		return localStateData{}
+	}
+	return state
+}
+
+// saveLocalState persists local bookkeeping and resets the clock-tamper
+// reference point alongside it.
+func saveLocalState(state localStateData) {
+	dir, _, _, localStateFile := Paths()
+	os.MkdirAll(dir, 0700)
+
+	data, err := json.Marshal(state)
+	if err == nil {
+		os.WriteFile(localStateFile, data, 0600)
+	}
+	saveTimestamp()
+}
+
@@ -8,110 +8,301 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
-// GenerateMachineID creates a unique, hardware-based machine identifier
-// Uses multiple sources that are difficult to spoof
+// MachineIDProvider is one source GenerateMachineID mixes into the
+// composite machine ID. Providers are registered in machineIDProviders so
+// a new hardware/software source can be added without editing
+// GenerateMachineID itself.
+type MachineIDProvider interface {
+	// Name identifies the provider in Fingerprint's output, and decides
+	// the (alphabetical) order its contribution is mixed into the hash.
+	Name() string
+	// Read returns this provider's contribution and whether it was able
+	// to produce one on this system.
+	Read() (string, bool)
+}
+
+// machineIDProviders is every registered MachineIDProvider. Registration
+// order here doesn't matter - GenerateMachineID sorts by Name before
+// hashing, so adding a provider never reshuffles an existing machine ID.
+var machineIDProviders = []MachineIDProvider{
+	sysfsProvider{},
+	dmidecodeProvider{},
+	udevadmProvider{},
+	tpm2Provider{},
+	tpm2QuoteProvider{},
+}
+
+// GenerateMachineID creates a unique, hardware-based machine identifier by
+// hashing the contribution of every available MachineIDProvider, sorted by
+// name so the result doesn't depend on registration order.
 func GenerateMachineID() string {
+	contributions := Fingerprint()
+
+	names := make([]string, 0, len(contributions))
+	for name := range contributions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var combined strings.Builder
+	for _, name := range names {
+		combined.WriteString(contributions[name])
+	}
+
+	hash := sha256.Sum256([]byte(combined.String()))
+	return hex.EncodeToString(hash[:])
+}
+
+// Fingerprint returns every available provider's contribution, keyed by
+// provider name, so a user can see which component changed when their
+// license suddenly invalidates after a hardware swap.
+func Fingerprint() map[string]string {
+	contributions := make(map[string]string)
+	for _, p := range machineIDProviders {
+		if value, ok := p.Read(); ok {
+			contributions[p.Name()] = value
+		}
+	}
+	return contributions
+}
+
+// sysfsProvider combines the machine-id, DMI product UUID, root disk
+// serial, CPU model, GPU line, primary MAC, and hostname+user. Most come
+// from files under /etc, /sys and /proc; the root disk is located with df,
+// the GPU line comes from lspci, and the primary interface from ip route.
+type sysfsProvider struct{}
+
+func (sysfsProvider) Name() string { return "sysfs" }
+
+func (sysfsProvider) Read() (string, bool) {
 	var parts []string
-	
-	// 1. System machine-id (standard)
+
 	if data, err := os.ReadFile("/etc/machine-id"); err == nil {
 		parts = append(parts, strings.TrimSpace(string(data)))
 	}
-	
-	// 2. DMI Product UUID (hardware-based, harder to fake)
 	if data, err := os.ReadFile("/sys/class/dmi/id/product_uuid"); err == nil {
 		parts = append(parts, strings.TrimSpace(string(data)))
-	} else {
-		// Try dmidecode as fallback (requires root typically)
-		if out, err := exec.Command("dmidecode", "-s", "system-uuid").Output(); err == nil {
-			parts = append(parts, strings.TrimSpace(string(out)))
-		}
 	}
-	
-	// 3. Root disk serial number
-	rootDisk := getRootDiskSerial()
-	if rootDisk != "" {
-		parts = append(parts, rootDisk)
+	if serial := sysfsDiskSerial(); serial != "" {
+		parts = append(parts, serial)
 	}
-	
-	// 4. Primary network interface MAC address
-	mac := getPrimaryMAC()
-	if mac != "" {
-		parts = append(parts, mac)
-	}
-	
-	// 5. CPU info
-	cpuInfo := getCPUInfo()
-	if cpuInfo != "" {
+	if cpuInfo := getCPUInfo(); cpuInfo != "" {
 		parts = append(parts, cpuInfo)
 	}
-	
-	// 6. GPU identifiers
-	gpuInfo := getGPUInfo()
-	if gpuInfo != "" {
+	if gpuInfo := getGPUInfo(); gpuInfo != "" {
 		parts = append(parts, gpuInfo)
 	}
-	
-	// 7. Hostname + username
+	if mac := getPrimaryMAC(); mac != "" {
+		parts = append(parts, mac)
+	}
 	if hostname, err := os.Hostname(); err == nil {
 		parts = append(parts, hostname)
 	}
-	
 	if u, err := user.Current(); err == nil {
 		parts = append(parts, u.Username)
 	}
-	
-	// Hash everything with SHA-256
-	combined := strings.Join(parts, "")
-	hash := sha256.Sum256([]byte(combined))
-	return hex.EncodeToString(hash[:])
+
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, ""), true
+}
+
+// dmidecodeProvider shells out to dmidecode for the system UUID, only
+// contributing when sysfs's DMI product UUID isn't readable - dmidecode
+// typically needs root, so this only kicks in as a fallback.
+type dmidecodeProvider struct{}
+
+func (dmidecodeProvider) Name() string { return "dmidecode" }
+
+func (dmidecodeProvider) Read() (string, bool) {
+	if _, err := os.ReadFile("/sys/class/dmi/id/product_uuid"); err == nil {
+		return "", false
+	}
+
+	out, err := exec.Command("dmidecode", "-s", "system-uuid").Output()
+	if err != nil {
+		return "", false
+	}
+	value := strings.TrimSpace(string(out))
+	if value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+// udevadmProvider shells out to udevadm for the root disk's serial number,
+// only contributing when sysfs doesn't expose one directly.
+type udevadmProvider struct{}
+
+func (udevadmProvider) Name() string { return "udevadm" }
+
+func (udevadmProvider) Read() (string, bool) {
+	if sysfsDiskSerial() != "" {
+		return "", false
+	}
+
+	device := rootDiskDevice()
+	if device == "" {
+		return "", false
+	}
+
+	out, err := exec.Command("udevadm", "info", "--query=property", "--name=/dev/"+device).Output()
+	if err != nil {
+		return "", false
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "ID_SERIAL=") {
+			if value := strings.TrimPrefix(line, "ID_SERIAL="); value != "" {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// tpm2Provider mixes in the TPM's endorsement key digest and PCR[0,1,7]
+// values when a TPM resource manager is present. It shells out to
+// tpm2-tools rather than linking a TPM library, the same way the other
+// providers here call external tools instead of a Go package.
+type tpm2Provider struct{}
+
+func (tpm2Provider) Name() string { return "tpm2" }
+
+func (tpm2Provider) Read() (string, bool) {
+	if _, err := os.Stat("/dev/tpmrm0"); err != nil {
+		return "", false
+	}
+
+	var parts []string
+
+	if out, err := exec.Command("tpm2_readpublic", "-c", "0x81010001").Output(); err == nil {
+		hash := sha256.Sum256(out)
+		parts = append(parts, hex.EncodeToString(hash[:]))
+	}
+	if out, err := exec.Command("tpm2_pcrread", "sha256:0,1,7").Output(); err == nil {
+		parts = append(parts, strings.TrimSpace(string(out)))
+	}
+
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, "|"), true
+}
+
+// tpm2QuoteAKHandle is the persistent handle tpm2QuoteProvider evicts its
+// attestation key to, analogous to tpm2Provider's fixed EK handle
+// (0x81010001). A quote's digest depends on the signing key, so the AK has
+// to live at a fixed handle across invocations - minting a fresh one every
+// call would make the quote (and so the machine ID) different every time
+// even on completely unchanged hardware.
+const tpm2QuoteAKHandle = "0x81010002"
+
+// tpm2QuoteProvider asks the TPM to quote (sign) PCR[0,1,7] against a
+// persistent attestation key, created once and evicted to a fixed handle.
+// Unlike a plain PCR read, a quote can't be replayed by software pretending
+// to be the TPM, so it contributes a stronger attestation signal than
+// tpm2Provider alone. Only contributes when a TPM resource manager is
+// present.
+type tpm2QuoteProvider struct{}
+
+func (tpm2QuoteProvider) Name() string { return "tpm2-quote" }
+
+func (tpm2QuoteProvider) Read() (string, bool) {
+	if _, err := os.Stat("/dev/tpmrm0"); err != nil {
+		return "", false
+	}
+
+	if err := ensureTPM2QuoteAK(); err != nil {
+		return "", false
+	}
+
+	tmpDir, err := os.MkdirTemp("", "wizado-tpm-quote")
+	if err != nil {
+		return "", false
+	}
+	defer os.RemoveAll(tmpDir)
+
+	quoteMsg := filepath.Join(tmpDir, "quote.msg")
+	quoteSig := filepath.Join(tmpDir, "quote.sig")
+
+	if err := exec.Command("tpm2_quote", "-c", tpm2QuoteAKHandle, "-l", "sha256:0,1,7",
+		"-m", quoteMsg, "-s", quoteSig).Run(); err != nil {
+		return "", false
+	}
+
+	msg, err := os.ReadFile(quoteMsg)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.Sum256(msg)
+	return hex.EncodeToString(hash[:]), true
+}
+
+// ensureTPM2QuoteAK makes sure an attestation key is persisted at
+// tpm2QuoteAKHandle, creating and evicting one the first time this runs on
+// a given TPM. Later calls find the handle already populated and do
+// nothing, so every quote on unchanged hardware signs with the same key.
+func ensureTPM2QuoteAK() error {
+	if err := exec.Command("tpm2_readpublic", "-c", tpm2QuoteAKHandle).Run(); err == nil {
+		return nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "wizado-tpm-ak")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	akCtx := filepath.Join(tmpDir, "ak.ctx")
+	if err := exec.Command("tpm2_createak", "-C", "0x81010001", "-c", akCtx).Run(); err != nil {
+		return err
+	}
+	return exec.Command("tpm2_evictcontrol", "-c", akCtx, tpm2QuoteAKHandle).Run()
 }
 
-// getRootDiskSerial gets the serial number of the root disk
-func getRootDiskSerial() string {
-	// Find root disk device
+// rootDiskDevice finds the root filesystem's underlying block device name
+// (e.g. "sda"), stripping any partition number.
+func rootDiskDevice() string {
 	out, err := exec.Command("df", "/").Output()
 	if err != nil {
 		return ""
 	}
-	
+
 	lines := strings.Split(string(out), "\n")
 	if len(lines) < 2 {
 		return ""
 	}
-	
+
 	fields := strings.Fields(lines[1])
 	if len(fields) == 0 {
 		return ""
 	}
-	
-	device := fields[0]
-	// Strip partition number (e.g., /dev/sda1 -> sda)
-	device = filepath.Base(device)
+
+	device := filepath.Base(fields[0])
 	for len(device) > 0 && device[len(device)-1] >= '0' && device[len(device)-1] <= '9' {
 		device = device[:len(device)-1]
 	}
-	
-	// Try to read serial from sysfs
-	serialPath := filepath.Join("/sys/block", device, "device/serial")
-	if data, err := os.ReadFile(serialPath); err == nil {
-		return strings.TrimSpace(string(data))
-	}
-	
-	// Try udevadm
-	out, err = exec.Command("udevadm", "info", "--query=property", "--name=/dev/"+device).Output()
-	if err == nil {
-		for _, line := range strings.Split(string(out), "\n") {
-			if strings.HasPrefix(line, "ID_SERIAL=") {
-				return strings.TrimPrefix(line, "ID_SERIAL=")
-			}
-		}
+	return device
+}
+
+// sysfsDiskSerial reads the root disk's serial number from sysfs, after
+// rootDiskDevice has located the disk via df.
+func sysfsDiskSerial() string {
+	device := rootDiskDevice()
+	if device == "" {
+		return ""
 	}
-	
-	return ""
+
+	data, err := os.ReadFile(filepath.Join("/sys/block", device, "device/serial"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
 }
 
 // getPrimaryMAC gets the MAC address of the primary network interface
@@ -121,13 +312,13 @@ func getPrimaryMAC() string {
 	if err != nil {
 		return ""
 	}
-	
+
 	// Parse "dev ethX" from output
 	parts := strings.Fields(string(out))
 	for i, part := range parts {
 		if part == "dev" && i+1 < len(parts) {
 			ifaceName := parts[i+1]
-			
+
 			// Get MAC address
 			iface, err := net.InterfaceByName(ifaceName)
 			if err == nil && len(iface.HardwareAddr) > 0 {
@@ -135,7 +326,7 @@ func getPrimaryMAC() string {
 			}
 		}
 	}
-	
+
 	return ""
 }
 
@@ -145,7 +336,7 @@ func getCPUInfo() string {
 	if err != nil {
 		return ""
 	}
-	
+
 	var parts []string
 	for _, line := range strings.Split(string(data), "\n") {
 		if strings.HasPrefix(line, "model name") || strings.HasPrefix(line, "cpu family") {
@@ -157,7 +348,7 @@ func getCPUInfo() string {
 			}
 		}
 	}
-	
+
 	return strings.Join(parts, "|")
 }
 
@@ -167,14 +358,13 @@ func getGPUInfo() string {
 	if err != nil {
 		return ""
 	}
-	
+
 	for _, line := range strings.Split(string(out), "\n") {
 		lower := strings.ToLower(line)
 		if strings.Contains(lower, "vga") || strings.Contains(lower, "3d") || strings.Contains(lower, "display") {
 			return strings.TrimSpace(line)
 		}
 	}
-	
+
 	return ""
 }
-
@@ -0,0 +1,106 @@
+package license
+
+import (
+	"sync"
+	"time"
+)
+
+// checkInterval is how often Manager re-checks license state in the
+// background. Most ticks are a cheap local blob+expiry check; actual
+// network re-verification still only happens every reverifyDays, same as
+// a direct Check() call.
+const checkInterval = 1 * time.Hour
+
+// Manager runs a single background goroutine that periodically calls
+// Check, caches the latest Result, and notifies subscribers when a new one
+// is produced. This lets callers that render on every frame - notably the
+// TUI - read from Snapshot() instead of re-reading the license file,
+// re-verifying its signature, and potentially hitting the network on every
+// keystroke.
+type Manager struct {
+	mu       sync.RWMutex
+	latest   Result
+	watchers []func(Result)
+
+	refreshCh chan struct{}
+	stopCh    chan struct{}
+}
+
+// NewManager creates a Manager, runs an initial synchronous Check, and
+// starts its background refresh loop.
+func NewManager() *Manager {
+	m := &Manager{
+		refreshCh: make(chan struct{}, 1),
+		stopCh:    make(chan struct{}),
+	}
+	m.latest = Check()
+	go m.run()
+	return m
+}
+
+// Subscribe registers fn to be called, from the Manager's goroutine,
+// whenever a check produces a Result. fn is also called once immediately
+// with the current snapshot so subscribers don't have to wait for the
+// first tick.
+func (m *Manager) Subscribe(fn func(Result)) {
+	m.mu.Lock()
+	m.watchers = append(m.watchers, fn)
+	current := m.latest
+	m.mu.Unlock()
+
+	fn(current)
+}
+
+// Snapshot returns the most recently cached Result without touching disk or
+// network.
+func (m *Manager) Snapshot() Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latest
+}
+
+// Refresh forces an immediate re-check on the background goroutine instead
+// of waiting for the next tick - used after activation so watchers see the
+// new license right away.
+func (m *Manager) Refresh() {
+	select {
+	case m.refreshCh <- struct{}{}:
+	default:
+		// a refresh is already pending
+	}
+}
+
+// Stop terminates the background goroutine. The Manager must not be used
+// afterwards.
+func (m *Manager) Stop() {
+	close(m.stopCh)
+}
+
+func (m *Manager) run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.check()
+		case <-m.refreshCh:
+			m.check()
+		case <-m.stopCh:
+			return
+		}
+	}
+}
+
+func (m *Manager) check() {
+	result := Check()
+
+	m.mu.Lock()
+	m.latest = result
+	watchers := append([]func(Result){}, m.watchers...)
+	m.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn(result)
+	}
+}
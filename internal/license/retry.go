@@ -0,0 +1,172 @@
+package license
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Retry/backoff tuning for calls to apiURL. These apply per network call,
+// not per Check()/Verify() cycle, so a single re-verification still only
+// costs a handful of requests even under sustained packet loss.
+const (
+	retryMaxAttempts = 4
+	retryBaseDelay   = 500 * time.Millisecond
+	retryMaxDelay    = 8 * time.Second
+
+	// circuitFailureThreshold consecutive network failures (across all three
+	// API calls, since they all hit the same host) before the breaker opens.
+	circuitFailureThreshold = 5
+	circuitCooldown         = 30 * time.Second
+)
+
+// circuitBreaker trips after consecutive failures and stays open for a
+// cooldown window, so a dead wizado.app fails fast instead of making every
+// caller - notably the TUI's activation flow - sit through a full retry
+// budget on each attempt.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{}
+}
+
+// allow reports whether a call may proceed, or whether the breaker is
+// currently open.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= circuitFailureThreshold {
+		b.openUntil = time.Now().Add(circuitCooldown)
+	}
+}
+
+// apiBreaker is shared across VerifyAPI, ActivateAPI, and RecoverAPI: they
+// all hit the same host, so a string of failures on one should fail the
+// others fast too.
+var apiBreaker = newCircuitBreaker()
+
+// doWithRetry sends the request built by newReq, retrying on network errors
+// and 5xx/429 responses with jittered exponential backoff, and honoring a
+// 429 response's Retry-After header. newReq is called again on every
+// attempt so a fresh *http.Request (and body reader) is used each time.
+func doWithRetry(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, error) {
+	if !apiBreaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	client := &http.Client{Timeout: apiTimeout}
+	backoff := retryBaseDelay
+
+	var lastErr error
+	for attempt := 0; attempt < retryMaxAttempts; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			apiBreaker.recordFailure()
+			lastErr = ErrNetworkError
+			if !sleepBackoff(ctx, backoff) {
+				return nil, ctx.Err()
+			}
+			backoff = nextBackoff(backoff)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := retryAfterDelay(resp, backoff)
+			resp.Body.Close()
+			apiBreaker.recordFailure()
+			if attempt == retryMaxAttempts-1 {
+				return nil, ErrRateLimited
+			}
+			if !sleepBackoff(ctx, wait) {
+				return nil, ctx.Err()
+			}
+			backoff = nextBackoff(backoff)
+			continue
+		}
+
+		if resp.StatusCode >= 500 {
+			resp.Body.Close()
+			apiBreaker.recordFailure()
+			lastErr = ErrNetworkError
+			if !sleepBackoff(ctx, backoff) {
+				return nil, ctx.Err()
+			}
+			backoff = nextBackoff(backoff)
+			continue
+		}
+
+		apiBreaker.recordSuccess()
+		return resp, nil
+	}
+
+	if lastErr == nil {
+		lastErr = ErrNetworkError
+	}
+	return nil, lastErr
+}
+
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	return d
+}
+
+// sleepBackoff waits a jittered fraction of d (50%-150%), returning false if
+// ctx is cancelled first.
+func sleepBackoff(ctx context.Context, d time.Duration) bool {
+	jittered := d/2 + time.Duration(rand.Int63n(int64(d)+1))
+	select {
+	case <-time.After(jittered):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// retryAfterDelay parses a 429 response's Retry-After header (seconds or an
+// HTTP date), falling back to the caller's own backoff if absent or
+// unparseable.
+func retryAfterDelay(resp *http.Response, fallback time.Duration) time.Duration {
+	h := resp.Header.Get("Retry-After")
+	if h == "" {
+		return fallback
+	}
+	if secs, err := strconv.Atoi(h); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
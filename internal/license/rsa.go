@@ -0,0 +1,99 @@
+package license
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+// Note on request chunk8-4 ("Argon2id-derived HMAC secret with per-install
+// salt file"): it asks to harden generateHMACSecret/VerifySignature, a
+// machine-fact-derived HMAC scheme that signed license blobs locally. That
+// scheme was already removed in favor of the RSA signing below - see the
+// "Replace HMAC license signatures with offline-verifiable RSA-signed
+// blobs" change. An RSA/Ed25519 signature verified against a key pinned in
+// the binary isn't forgeable by reading local files the way an HMAC keyed
+// off /etc/machine-id and product_uuid is, so strengthening the KDF that
+// used to derive that secret wouldn't close a real gap here; there's no
+// HMAC secret left in this codebase for Argon2id to protect.
+
+// licensePublicKeyPEM is wattfource's RSA-2048 public key, pinned at compile
+// time. The matching private key never ships in this binary - it's held
+// offline and used only by the license-issuing tool to sign blobs handed to
+// customers, so a license is verifiable entirely offline while remaining
+// unforgeable without that key.
+const licensePublicKeyPEM = `-----BEGIN PUBLIC KEY-----
+MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA29pHXuSTC0xH1YwBKhVe
+ktyHx0ZCtcOQ9s6fcv9igEFkGdOGl6n16R43Vnral12ENS7YqPnck3imWCR0jgfI
+J2u4YMIwK2WU4ySQpfwnWdGrwoEAtIg2CKdRbNJrF6pgXnt4fUhigE1rtNEbyNSV
+qyShx+bzvo3miM/DOp49NJrsElYtgLzEJ3fT4vdteY1swfqp/FYedlwpu2wnrlZl
+fcOc8oQsXvjoqwtujoSxgQmiUZ+cx1l2rfW7793kclNNpBfZAEjgpDx/P5/SBlt+
+8r2HlEtpuyQHkhYTwya8NdBgGx0/PAiRY8NNhtduq4d7kZV4/DnAcSMCqFd6KL2e
+uwIDAQAB
+-----END PUBLIC KEY-----`
+
+// rsaSignatureSize is the PKCS#1 v1.5 signature length for a 2048-bit key,
+// used to split a decoded blob into its payload and signature without a
+// delimiter.
+const rsaSignatureSize = 256
+
+// licensePublicKey parses the pinned PEM constant once per call; it's cheap
+// enough that caching isn't worth the complexity here.
+func licensePublicKey() (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(licensePublicKeyPEM))
+	if block == nil {
+		return nil, errors.New("license: invalid pinned public key")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("license: pinned public key is not RSA")
+	}
+	return rsaPub, nil
+}
+
+// parseLicenseBlob decodes a base64 license blob into its JSON payload and
+// trailing RSA-PKCS#1v15/SHA-256 signature, verifies the signature against
+// the pinned public key, and unmarshals the payload. Any failure - bad
+// base64, a too-short blob, a signature that doesn't verify, or invalid
+// JSON - is reported as ErrTampered.
+func parseLicenseBlob(blob string) (*LicensePayload, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(blob))
+	if err != nil {
+		return nil, ErrTampered
+	}
+	if len(raw) <= rsaSignatureSize {
+		return nil, ErrTampered
+	}
+
+	payloadJSON := raw[:len(raw)-rsaSignatureSize]
+	signature := raw[len(raw)-rsaSignatureSize:]
+
+	pub, err := licensePublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(payloadJSON)
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], signature); err != nil {
+		return nil, ErrTampered
+	}
+
+	var payload LicensePayload
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, ErrTampered
+	}
+
+	return &payload, nil
+}
@@ -3,12 +3,11 @@
 package logging
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 )
@@ -40,34 +39,64 @@ func (l Level) String() string {
 
 // Entry represents a single log entry
 type Entry struct {
-	Timestamp time.Time         `json:"timestamp"`
-	Level     string            `json:"level"`
-	Message   string            `json:"message"`
-	Component string            `json:"component,omitempty"`
-	Fields    map[string]any    `json:"fields,omitempty"`
-	Caller    string            `json:"caller,omitempty"`
-}
-
-// Logger provides structured logging
+	Timestamp time.Time      `json:"timestamp"`
+	Level     string         `json:"level"`
+	Message   string         `json:"message"`
+	Component string         `json:"component,omitempty"`
+	Fields    map[string]any `json:"fields,omitempty"`
+	Caller    string         `json:"caller,omitempty"`
+	Error     string         `json:"error,omitempty"`
+	Stack     string         `json:"stack,omitempty"`
+}
+
+// Logger provides structured logging. Entries are pushed onto a bounded
+// ring buffer (entryQueue) rather than written or fanned out to sinks on
+// the caller's goroutine, so a stalled sink can never block the logging
+// call site itself.
 type Logger struct {
 	mu        sync.Mutex
 	level     Level
-	output    io.Writer
-	file      *os.File
-	filePath  string
 	component string
 	fields    map[string]any
-	maxSize   int64 // Max file size in bytes before rotation
 	jsonMode  bool
+	sinks     []Sink
+	queue     *entryQueue
+	sampler   *sampler
+	groups    []string // nested via WithGroup, innermost last
+	err       error    // set by WithError; attached (with a stack trace) at level >= ERROR
 }
 
 // Config holds logger configuration
 type Config struct {
 	Level     Level
 	FilePath  string
-	MaxSizeMB int  // Max log file size in MB (default 10)
+	MaxSizeMB int  // Max log file size in MB (default 10), used by the implicit file sink
 	JSONMode  bool // Output as JSON
 	Component string
+
+	// Compress, MaxBackups, and MaxAgeDays control the implicit file sink's
+	// rotation: Compress gzips a backup once a newer rotation displaces it,
+	// MaxBackups caps how many rotated files are kept (default 5), and
+	// MaxAgeDays deletes rotated files older than that regardless of count.
+	Compress   bool
+	MaxBackups int
+	MaxAgeDays int
+
+	// Sinks lets ~/.config/wizado/logging.json wire up additional logging
+	// destinations without recompiling. If empty, New falls back to a
+	// single file sink built from FilePath/MaxSizeMB, preserving the
+	// pre-Sinks behavior.
+	Sinks []SinkConfig
+
+	// QueueSize bounds the Logger's internal entry ring buffer (default
+	// 1024). OverflowPolicy decides what happens once it's full.
+	QueueSize      int
+	OverflowPolicy OverflowPolicy
+
+	// Sampling caps entries per second per (level, message template), so a
+	// hot loop calling Debugf every iteration can't swamp the sinks. Left
+	// unset, every level is unlimited.
+	Sampling SamplingConfig
 }
 
 // DefaultConfig returns default logger configuration
@@ -77,8 +106,13 @@ func DefaultConfig() Config {
 		Level:     LevelInfo,
 		FilePath:  filepath.Join(home, ".cache", "wizado", "wizado.log"),
 		MaxSizeMB: 10,
-		JSONMode:  false,
+		// JSON-lines so tools like the TUI's log viewer can parse entries
+		// instead of scraping the plain-text format.
+		JSONMode:  true,
 		Component: "wizado",
+
+		QueueSize:      1024,
+		OverflowPolicy: DropOldest,
 	}
 }
 
@@ -106,69 +140,82 @@ func Default() *Logger {
 
 // New creates a new logger
 func New(cfg Config) (*Logger, error) {
-	if cfg.MaxSizeMB <= 0 {
-		cfg.MaxSizeMB = 10
+	sinkCfgs := cfg.Sinks
+	if len(sinkCfgs) == 0 {
+		sinkCfgs = []SinkConfig{{
+			Type:       "file",
+			Path:       cfg.FilePath,
+			MaxSizeMB:  cfg.MaxSizeMB,
+			Compress:   cfg.Compress,
+			MaxBackups: cfg.MaxBackups,
+			MaxAgeDays: cfg.MaxAgeDays,
+		}}
+	}
+
+	sinks := make([]Sink, 0, len(sinkCfgs))
+	for _, sc := range sinkCfgs {
+		sink, err := buildSink(sc, cfg.JSONMode)
+		if err != nil {
+			// A misconfigured sink shouldn't take down the whole logger;
+			// fall back to stderr for that destination and keep going.
+			sinks = append(sinks, newWriterSink(os.Stderr, cfg.JSONMode))
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = 1024
 	}
 
 	l := &Logger{
 		level:     cfg.Level,
-		filePath:  cfg.FilePath,
 		component: cfg.Component,
-		maxSize:   int64(cfg.MaxSizeMB) * 1024 * 1024,
 		jsonMode:  cfg.JSONMode,
 		fields:    make(map[string]any),
-	}
-
-	if cfg.FilePath != "" {
-		if err := l.openFile(); err != nil {
-			// Fall back to stderr
-			l.output = os.Stderr
-		}
-	} else {
-		l.output = os.Stderr
+		sinks:     sinks,
+		queue:     newEntryQueue(queueSize, cfg.OverflowPolicy, sinks, cfg.JSONMode),
+		sampler:   newSampler(cfg.Sampling),
 	}
 
 	return l, nil
 }
 
-func (l *Logger) openFile() error {
-	dir := filepath.Dir(l.filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
+// Close drains the entry queue and then closes every configured sink.
+func (l *Logger) Close() error {
+	l.queue.close()
 
-	f, err := os.OpenFile(l.filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-
-	l.file = f
-	l.output = f
-	return nil
+	return firstErr
 }
 
-// Close closes the log file
-func (l *Logger) Close() error {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	if l.file != nil {
-		return l.file.Close()
-	}
-	return nil
+// Stats reports how many entries the Logger's queue has written and
+// dropped, how many are currently waiting to be dispatched to sinks, and
+// how many were dropped by sampling before ever reaching the queue.
+func (l *Logger) Stats() Stats {
+	stats := l.queue.stats()
+	stats.SampledDropped = l.sampler.droppedCount()
+	return stats
 }
 
 // WithComponent returns a new logger with the given component name
 func (l *Logger) WithComponent(component string) *Logger {
 	return &Logger{
 		level:     l.level,
-		output:    l.output,
-		file:      l.file,
-		filePath:  l.filePath,
 		component: component,
 		fields:    copyFields(l.fields),
-		maxSize:   l.maxSize,
 		jsonMode:  l.jsonMode,
+		sinks:     l.sinks,
+		queue:     l.queue,
+		sampler:   l.sampler,
+		groups:    l.groups,
+		err:       l.err,
 	}
 }
 
@@ -178,13 +225,14 @@ func (l *Logger) WithField(key string, value any) *Logger {
 	fields[key] = value
 	return &Logger{
 		level:     l.level,
-		output:    l.output,
-		file:      l.file,
-		filePath:  l.filePath,
 		component: l.component,
 		fields:    fields,
-		maxSize:   l.maxSize,
 		jsonMode:  l.jsonMode,
+		sinks:     l.sinks,
+		queue:     l.queue,
+		sampler:   l.sampler,
+		groups:    l.groups,
+		err:       l.err,
 	}
 }
 
@@ -196,13 +244,51 @@ func (l *Logger) WithFields(fields map[string]any) *Logger {
 	}
 	return &Logger{
 		level:     l.level,
-		output:    l.output,
-		file:      l.file,
-		filePath:  l.filePath,
 		component: l.component,
 		fields:    newFields,
-		maxSize:   l.maxSize,
 		jsonMode:  l.jsonMode,
+		sinks:     l.sinks,
+		queue:     l.queue,
+		sampler:   l.sampler,
+		groups:    l.groups,
+		err:       l.err,
+	}
+}
+
+// WithError returns a new logger carrying err. If a subsequent call is made
+// at LevelError or above, the entry's Error field is set to err.Error() and
+// its Stack field gets a formatted stack trace captured at that call site.
+func (l *Logger) WithError(err error) *Logger {
+	return &Logger{
+		level:     l.level,
+		component: l.component,
+		fields:    copyFields(l.fields),
+		jsonMode:  l.jsonMode,
+		sinks:     l.sinks,
+		queue:     l.queue,
+		sampler:   l.sampler,
+		groups:    l.groups,
+		err:       err,
+	}
+}
+
+// WithGroup returns a new logger whose fields nest under name when emitted
+// as JSON, matching log/slog's grouping: a second WithGroup nests one level
+// deeper rather than replacing the first.
+func (l *Logger) WithGroup(name string) *Logger {
+	groups := make([]string, len(l.groups), len(l.groups)+1)
+	copy(groups, l.groups)
+	groups = append(groups, name)
+	return &Logger{
+		level:     l.level,
+		component: l.component,
+		fields:    copyFields(l.fields),
+		jsonMode:  l.jsonMode,
+		sinks:     l.sinks,
+		queue:     l.queue,
+		sampler:   l.sampler,
+		groups:    groups,
+		err:       l.err,
 	}
 }
 
@@ -223,54 +309,57 @@ func (l *Logger) SetLevel(level Level) {
 
 // Debug logs a debug message
 func (l *Logger) Debug(msg string) {
-	l.log(LevelDebug, msg, nil)
+	l.log(LevelDebug, msg, msg, nil)
 }
 
 // Debugf logs a formatted debug message
 func (l *Logger) Debugf(format string, args ...any) {
-	l.log(LevelDebug, fmt.Sprintf(format, args...), nil)
+	l.log(LevelDebug, format, fmt.Sprintf(format, args...), nil)
 }
 
 // Info logs an info message
 func (l *Logger) Info(msg string) {
-	l.log(LevelInfo, msg, nil)
+	l.log(LevelInfo, msg, msg, nil)
 }
 
 // Infof logs a formatted info message
 func (l *Logger) Infof(format string, args ...any) {
-	l.log(LevelInfo, fmt.Sprintf(format, args...), nil)
+	l.log(LevelInfo, format, fmt.Sprintf(format, args...), nil)
 }
 
 // Warn logs a warning message
 func (l *Logger) Warn(msg string) {
-	l.log(LevelWarn, msg, nil)
+	l.log(LevelWarn, msg, msg, nil)
 }
 
 // Warnf logs a formatted warning message
 func (l *Logger) Warnf(format string, args ...any) {
-	l.log(LevelWarn, fmt.Sprintf(format, args...), nil)
+	l.log(LevelWarn, format, fmt.Sprintf(format, args...), nil)
 }
 
 // Error logs an error message
 func (l *Logger) Error(msg string) {
-	l.log(LevelError, msg, nil)
+	l.log(LevelError, msg, msg, nil)
 }
 
 // Errorf logs a formatted error message
 func (l *Logger) Errorf(format string, args ...any) {
-	l.log(LevelError, fmt.Sprintf(format, args...), nil)
+	l.log(LevelError, format, fmt.Sprintf(format, args...), nil)
 }
 
-func (l *Logger) log(level Level, msg string, extraFields map[string]any) {
-	if level < l.level {
+// log builds and dispatches an entry. template identifies the call site for
+// sampling purposes: it's the literal format string for *f variants (stable
+// across calls even as args change) and the message itself otherwise.
+func (l *Logger) log(level Level, template, msg string, extraFields map[string]any) {
+	l.mu.Lock()
+	lvl := l.level
+	l.mu.Unlock()
+	if level < lvl {
+		return
+	}
+	if !l.sampler.allow(level, template) {
 		return
 	}
-
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	// Check for rotation
-	l.rotateIfNeeded()
 
 	entry := Entry{
 		Timestamp: time.Now().UTC(),
@@ -279,91 +368,86 @@ func (l *Logger) log(level Level, msg string, extraFields map[string]any) {
 		Component: l.component,
 	}
 
-	// Merge fields
+	// Merge fields, nesting under any WithGroup namespace
 	if len(l.fields) > 0 || len(extraFields) > 0 {
-		entry.Fields = make(map[string]any)
+		fields := make(map[string]any, len(l.fields)+len(extraFields))
 		for k, v := range l.fields {
-			entry.Fields[k] = v
+			fields[k] = v
 		}
 		for k, v := range extraFields {
-			entry.Fields[k] = v
+			fields[k] = v
 		}
+		entry.Fields = nestFields(l.groups, fields)
 	}
 
-	// Add caller info for debug and error levels
-	if level == LevelDebug || level == LevelError {
-		if _, file, line, ok := runtime.Caller(2); ok {
-			entry.Caller = fmt.Sprintf("%s:%d", filepath.Base(file), line)
-		}
-	}
+	// Caller is captured for every level now, not just debug/error - it's
+	// cheap relative to the rest of log() and uniformly useful for tracing
+	// an entry back to its call site.
+	entry.Caller = callerFrame()
 
-	// Format output
-	var output string
-	if l.jsonMode {
-		data, _ := json.Marshal(entry)
-		output = string(data)
-	} else {
-		output = l.formatPlain(entry)
+	if l.err != nil && level >= LevelError {
+		entry.Error = l.err.Error()
+		entry.Stack = formatStack()
 	}
 
-	fmt.Fprintln(l.output, output)
+	l.queue.push(entry)
 }
 
-func (l *Logger) formatPlain(e Entry) string {
-	ts := e.Timestamp.Format("2006-01-02 15:04:05")
-	
-	var result string
-	if e.Component != "" {
-		result = fmt.Sprintf("[%s] [%s] [%s] %s", ts, e.Level, e.Component, e.Message)
-	} else {
-		result = fmt.Sprintf("[%s] [%s] %s", ts, e.Level, e.Message)
-	}
-
-	if len(e.Fields) > 0 {
-		for k, v := range e.Fields {
-			result += fmt.Sprintf(" %s=%v", k, v)
-		}
+// callerFrame returns "file:line" for the call site that invoked one of the
+// Logger's exported logging methods (Debug, Infof, and so on).
+func callerFrame() string {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(4, pcs) == 0 {
+		return ""
 	}
-
-	if e.Caller != "" {
-		result += fmt.Sprintf(" (%s)", e.Caller)
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	if frame.File == "" {
+		return ""
 	}
-
-	return result
+	return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
 }
 
-func (l *Logger) rotateIfNeeded() {
-	if l.file == nil || l.maxSize <= 0 {
-		return
+// formatStack renders the goroutine's stack above formatStack itself as
+// "func\n\tfile:line" lines, for attaching to entries logged via WithError.
+func formatStack() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	if n == 0 {
+		return ""
 	}
 
-	stat, err := l.file.Stat()
-	if err != nil {
-		return
-	}
-
-	if stat.Size() < l.maxSize {
-		return
+	frames := runtime.CallersFrames(pcs[:n])
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
 	}
+	return b.String()
+}
 
-	// Close current file
-	l.file.Close()
-
-	// Rotate: rename current to .1, .1 to .2, etc.
-	for i := 4; i >= 1; i-- {
-		old := fmt.Sprintf("%s.%d", l.filePath, i)
-		new := fmt.Sprintf("%s.%d", l.filePath, i+1)
-		os.Rename(old, new)
+// nestFields wraps fields under groups, innermost group last, matching
+// log/slog's WithGroup nesting when an entry is rendered as JSON.
+func nestFields(groups []string, fields map[string]any) map[string]any {
+	nested := fields
+	for i := len(groups) - 1; i >= 0; i-- {
+		nested = map[string]any{groups[i]: nested}
 	}
-	os.Rename(l.filePath, l.filePath+".1")
-
-	// Open new file
-	l.openFile()
+	return nested
 }
 
-// LogPath returns the path to the log file
+// LogPath returns the path of the logger's file sink, or "" if it has none
+// (e.g. because it's configured with only network sinks, or the file
+// couldn't be opened and fell back to stderr).
 func (l *Logger) LogPath() string {
-	return l.filePath
+	for _, sink := range l.sinks {
+		if fs, ok := sink.(*fileSink); ok {
+			return fs.LogPath()
+		}
+	}
+	return ""
 }
 
 // Global helper functions that use the default logger
@@ -423,6 +507,16 @@ func WithFields(fields map[string]any) *Logger {
 	return Default().WithFields(fields)
 }
 
+// WithError returns a logger carrying err
+func WithError(err error) *Logger {
+	return Default().WithError(err)
+}
+
+// WithGroup returns a logger whose fields nest under name
+func WithGroup(name string) *Logger {
+	return Default().WithGroup(name)
+}
+
 // SessionLogger creates a logger for a gaming session
 func SessionLogger(sessionID string) *Logger {
 	return Default().WithComponent("session").WithField("session_id", sessionID)
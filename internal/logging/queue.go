@@ -0,0 +1,184 @@
+package logging
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what entryQueue does when its ring buffer is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest evicts the oldest queued entry to make room for the new
+	// one, favoring recency - the right choice for a live session where
+	// the newest state matters most.
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the incoming entry and keeps the queue as-is.
+	DropNewest
+)
+
+func (p OverflowPolicy) String() string {
+	if p == DropNewest {
+		return "drop_newest"
+	}
+	return "drop_oldest"
+}
+
+// Stats summarizes a Logger's queue health.
+type Stats struct {
+	EntriesWritten uint64
+	BytesWritten   uint64
+	Dropped        uint64
+	QueueDepth     int
+	SampledDropped uint64 // entries dropped by sampling before reaching the queue
+}
+
+// dropReportInterval is how often a pending drop count is flushed out as a
+// synthetic WARN entry, so overflow is visible in the log stream itself
+// rather than only in Stats().
+const dropReportInterval = 10 * time.Second
+
+// entryQueue is the bounded ring buffer Logger.log enqueues onto instead of
+// writing (or even fanning out to sinks) on the caller's goroutine. A
+// single dedicated writer goroutine drains it and dispatches to sinks, so
+// SessionLogger calls on the game hot path never stall behind a slow sink.
+type entryQueue struct {
+	mu     sync.Mutex
+	buf    []Entry
+	cap    int
+	policy OverflowPolicy
+
+	wake chan struct{} // buffered(1): signals the writer there's work
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	written            uint64 // atomic
+	bytes              uint64 // atomic
+	droppedTotal       uint64 // atomic, cumulative, surfaced via Stats
+	droppedSinceReport uint64 // atomic, reset each time the synthetic WARN fires
+
+	sinks    []Sink
+	jsonMode bool
+}
+
+func newEntryQueue(capacity int, policy OverflowPolicy, sinks []Sink, jsonMode bool) *entryQueue {
+	q := &entryQueue{
+		cap:      capacity,
+		policy:   policy,
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		sinks:    sinks,
+		jsonMode: jsonMode,
+	}
+	q.wg.Add(1)
+	go q.run()
+	return q
+}
+
+// push enqueues e, applying the overflow policy if the buffer is full.
+// Never blocks: the caller's hot path only ever takes a short-held mutex
+// over an in-memory slice, never disk or network I/O.
+func (q *entryQueue) push(e Entry) {
+	q.mu.Lock()
+	if len(q.buf) >= q.cap {
+		if q.policy == DropOldest && len(q.buf) > 0 {
+			q.buf = q.buf[1:]
+			atomic.AddUint64(&q.droppedTotal, 1)
+			atomic.AddUint64(&q.droppedSinceReport, 1)
+		} else {
+			q.mu.Unlock()
+			atomic.AddUint64(&q.droppedTotal, 1)
+			atomic.AddUint64(&q.droppedSinceReport, 1)
+			return
+		}
+	}
+	q.buf = append(q.buf, e)
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *entryQueue) popAll() []Entry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.buf) == 0 {
+		return nil
+	}
+	out := q.buf
+	q.buf = nil
+	return out
+}
+
+func (q *entryQueue) depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.buf)
+}
+
+func (q *entryQueue) run() {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(dropReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.wake:
+			q.flush()
+		case <-ticker.C:
+			q.reportDrops()
+		case <-q.stop:
+			q.flush()
+			return
+		}
+	}
+}
+
+func (q *entryQueue) flush() {
+	for _, e := range q.popAll() {
+		q.dispatch(e)
+	}
+}
+
+func (q *entryQueue) dispatch(e Entry) {
+	atomic.AddUint64(&q.written, 1)
+	atomic.AddUint64(&q.bytes, uint64(len(formatEntry(e, q.jsonMode))))
+	for _, sink := range q.sinks {
+		sink.Enqueue(e)
+	}
+}
+
+// reportDrops emits a synthetic WARN entry summarizing drops since the
+// last report, if there were any. It goes straight through dispatch rather
+// than push, so it can never itself be counted as a drop.
+func (q *entryQueue) reportDrops() {
+	n := atomic.SwapUint64(&q.droppedSinceReport, 0)
+	if n == 0 {
+		return
+	}
+	q.dispatch(Entry{
+		Timestamp: time.Now().UTC(),
+		Level:     LevelWarn.String(),
+		Component: "logging",
+		Message:   fmt.Sprintf("dropped %d log entries (queue overflow)", n),
+	})
+}
+
+func (q *entryQueue) stats() Stats {
+	return Stats{
+		EntriesWritten: atomic.LoadUint64(&q.written),
+		BytesWritten:   atomic.LoadUint64(&q.bytes),
+		Dropped:        atomic.LoadUint64(&q.droppedTotal),
+		QueueDepth:     q.depth(),
+	}
+}
+
+func (q *entryQueue) close() {
+	close(q.stop)
+	q.wg.Wait()
+}
@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// rotateIfNeeded renames the active log file to ".1" once it exceeds
+// maxSize, shifting existing backups (".N" or ".N.gz") up one slot first.
+// Compression of the backup that just got displaced out of the newest slot,
+// and pruning by count/age, both happen in the background so a slow gzip
+// or a big directory listing never blocks the logging goroutine for long.
+func (fs *fileSink) rotateIfNeeded() {
+	if fs.file == nil || fs.maxSize <= 0 {
+		return
+	}
+
+	stat, err := fs.file.Stat()
+	if err != nil {
+		return
+	}
+	if stat.Size() < fs.maxSize {
+		return
+	}
+
+	fs.file.Close()
+
+	maxBackups := fs.maxBackups
+	if maxBackups <= 0 {
+		maxBackups = 5
+	}
+
+	for i := maxBackups; i >= 1; i-- {
+		srcPlain := fmt.Sprintf("%s.%d", fs.filePath, i)
+		srcGz := srcPlain + ".gz"
+		dstPlain := fmt.Sprintf("%s.%d", fs.filePath, i+1)
+		dstGz := dstPlain + ".gz"
+
+		if i+1 > maxBackups {
+			// This slot has aged out of the retained window entirely.
+			os.Remove(srcPlain)
+			os.Remove(srcGz)
+			os.Remove(srcGz + ".sha256")
+			continue
+		}
+
+		if _, err := os.Stat(srcGz); err == nil {
+			os.Rename(srcGz, dstGz)
+			os.Rename(srcGz+".sha256", dstGz+".sha256")
+		} else if _, err := os.Stat(srcPlain); err == nil {
+			os.Rename(srcPlain, dstPlain)
+		}
+	}
+
+	os.Rename(fs.filePath, fs.filePath+".1")
+
+	fs.file = nil
+	fs.openFile()
+
+	if fs.compress {
+		// ".2" is whatever just got displaced out of the newest slot by
+		// the shift above - compress it now that new writes have moved on.
+		go compressBackup(fmt.Sprintf("%s.2", fs.filePath))
+	}
+
+	go pruneBackups(fs.filePath, maxBackups, fs.maxAgeDays)
+}
+
+// compressBackup gzips path into path+".gz" plus a sha256 sidecar, then
+// removes the uncompressed original. Errors are reported to stderr rather
+// than through the logging package itself, since a sink can't sensibly log
+// through the very Logger it belongs to.
+func compressBackup(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return // already compressed, already pruned, or never existed
+	}
+	defer src.Close()
+
+	gzPath := path + ".gz"
+	dst, err := os.OpenFile(gzPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logging: compressing %s: %v\n", path, err)
+		return
+	}
+
+	hasher := sha256.New()
+	gw := gzip.NewWriter(io.MultiWriter(dst, hasher))
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		dst.Close()
+		os.Remove(gzPath)
+		fmt.Fprintf(os.Stderr, "logging: compressing %s: %v\n", path, err)
+		return
+	}
+	if err := gw.Close(); err != nil {
+		dst.Close()
+		os.Remove(gzPath)
+		fmt.Fprintf(os.Stderr, "logging: compressing %s: %v\n", path, err)
+		return
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(gzPath)
+		fmt.Fprintf(os.Stderr, "logging: compressing %s: %v\n", path, err)
+		return
+	}
+
+	// The checksum sidecar covers the pre-compression bytes, so
+	// VerifyBackup can confirm decompressed content still matches what
+	// was originally rotated, independent of gzip's own CRC.
+	sidecar := gzPath + ".sha256"
+	if err := os.WriteFile(sidecar, []byte(hex.EncodeToString(hasher.Sum(nil))), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "logging: writing checksum for %s: %v\n", gzPath, err)
+	}
+
+	os.Remove(path)
+}
+
+// pruneBackups deletes rotated files for filePath older than maxAgeDays,
+// regardless of whether the count-based shift above already retained them.
+// A maxAgeDays of 0 disables this check.
+func pruneBackups(filePath string, maxBackups, maxAgeDays int) {
+	if maxAgeDays <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-time.Duration(maxAgeDays) * 24 * time.Hour)
+
+	for i := 1; i <= maxBackups+1; i++ {
+		for _, suffix := range []string{"", ".gz"} {
+			path := fmt.Sprintf("%s.%d%s", filePath, i, suffix)
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if info.ModTime().Before(cutoff) {
+				os.Remove(path)
+				os.Remove(path + ".sha256")
+			}
+		}
+	}
+}
+
+// VerifyBackup recomputes the sha256 of a compressed backup (".N.gz") and
+// compares it against its ".sha256" sidecar, so tampering or truncation of
+// an archived log can be detected the same way telemetry's manifest does.
+func VerifyBackup(gzPath string) (bool, error) {
+	sidecar, err := os.ReadFile(gzPath + ".sha256")
+	if err != nil {
+		return false, err
+	}
+
+	f, err := os.Open(gzPath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return false, err
+	}
+	defer gr.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, gr); err != nil {
+		return false, err
+	}
+
+	want := strings.TrimSpace(string(sidecar))
+	got := hex.EncodeToString(hasher.Sum(nil))
+	return got == want, nil
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// SamplingConfig caps how many entries per second are actually dispatched
+// for a given level, keyed by message template (the literal format string
+// passed to Debugf/Infof/etc., or the message itself for non-f calls) so a
+// single chatty call site can't swamp the log while the rest of the program
+// logs normally. A level with no entry, or a PerSecond of 0, is unlimited.
+type SamplingConfig struct {
+	PerSecond map[Level]int
+}
+
+// tokenBucket refills at a fixed rate up to its capacity; each allowed entry
+// consumes one token.
+type tokenBucket struct {
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(perSecond int) *tokenBucket {
+	rate := float64(perSecond)
+	return &tokenBucket{rate: rate, capacity: rate, tokens: rate, last: time.Now()}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sampler gates log entries by (level, message template) using a token
+// bucket per key, so it allows steady background logging at a level while
+// still clamping a single loop that suddenly starts logging every iteration.
+type sampler struct {
+	mu      sync.Mutex
+	limits  map[Level]int
+	buckets map[string]*tokenBucket
+
+	dropped uint64 // atomic would be overkill here; always touched under mu
+}
+
+func newSampler(cfg SamplingConfig) *sampler {
+	return &sampler{
+		limits:  cfg.PerSecond,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow reports whether an entry at level, with the given message template,
+// may be dispatched. Templates with no configured limit (or limit <= 0) are
+// always allowed.
+func (s *sampler) allow(level Level, template string) bool {
+	if s == nil {
+		return true
+	}
+
+	limit, ok := s.limits[level]
+	if !ok || limit <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := level.String() + "|" + template
+	b, ok := s.buckets[key]
+	if !ok {
+		b = newTokenBucket(limit)
+		s.buckets[key] = b
+	}
+	if b.allow(time.Now()) {
+		return true
+	}
+	s.dropped++
+	return false
+}
+
+func (s *sampler) droppedCount() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
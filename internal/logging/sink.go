@@ -0,0 +1,351 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// sinkBufferSize bounds how many entries a sink queues before new ones are
+// dropped, keeping the hot logging path lock-free even if a sink's
+// destination (disk, network) is slow or stalled.
+const sinkBufferSize = 256
+
+// Sink is a single logging destination. Enqueue must never block the
+// caller on disk or network I/O - each implementation runs its own
+// buffered channel and background flusher goroutine.
+type Sink interface {
+	// Enqueue submits an entry for asynchronous delivery. If the sink's
+	// internal buffer is full, the entry is dropped.
+	Enqueue(Entry)
+
+	// Close stops the sink's flusher after draining whatever is already
+	// queued.
+	Close() error
+}
+
+// SinkConfig describes one entry in Config.Sinks, decoded from
+// ~/.config/wizado/logging.json so sinks can be wired up without
+// recompiling. Only the fields relevant to Type are used.
+type SinkConfig struct {
+	Type string `json:"type"` // "file", "stderr", "syslog", "http", "elasticsearch", "slack"
+
+	// file
+	Path       string `json:"path,omitempty"`
+	MaxSizeMB  int    `json:"max_size_mb,omitempty"`
+	Compress   bool   `json:"compress,omitempty"`    // gzip rotated backups once they age out of the newest slot
+	MaxBackups int    `json:"max_backups,omitempty"` // how many rotated files to keep, default 5
+	MaxAgeDays int    `json:"max_age_days,omitempty"` // delete rotated files older than this, 0 = no age cap
+
+	// syslog
+	Network  string `json:"network,omitempty"` // "", "udp", "tcp", or "tls" ("" means the local syslog socket)
+	Address  string `json:"address,omitempty"`
+	Facility string `json:"facility,omitempty"` // e.g. "user", "daemon", "local0" - default "user"
+	Tag      string `json:"tag,omitempty"`      // default "wizado"
+
+	// http and elasticsearch
+	Endpoint    string            `json:"endpoint,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Index       string            `json:"index,omitempty"` // elasticsearch only
+	BatchSize   int               `json:"batch_size,omitempty"`
+	FlushMillis int               `json:"flush_millis,omitempty"`
+	MaxRetries  int               `json:"max_retries,omitempty"`
+
+	// slack / generic incoming webhook
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// SinksConfigPath returns the path to the optional JSON file that lets
+// sinks be wired up without recompiling: an array of SinkConfig objects.
+func SinksConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "wizado", "logging.json")
+}
+
+// LoadSinks reads the sink list from SinksConfigPath(). It returns a nil
+// slice (not an error) if the file doesn't exist, so callers can fall back
+// to Config's default file sink.
+func LoadSinks() ([]SinkConfig, error) {
+	data, err := os.ReadFile(SinksConfigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sinks []SinkConfig
+	if err := json.Unmarshal(data, &sinks); err != nil {
+		return nil, fmt.Errorf("logging: parsing %s: %w", SinksConfigPath(), err)
+	}
+	return sinks, nil
+}
+
+// buildSink constructs the Sink described by cfg. jsonMode carries over
+// from the Logger's own Config so file/stderr sinks keep rendering entries
+// the way they did before sinks existed.
+func buildSink(cfg SinkConfig, jsonMode bool) (Sink, error) {
+	switch cfg.Type {
+	case "", "file":
+		path := cfg.Path
+		maxSizeMB := cfg.MaxSizeMB
+		if maxSizeMB <= 0 {
+			maxSizeMB = 10
+		}
+		maxBackups := cfg.MaxBackups
+		if maxBackups <= 0 {
+			maxBackups = 5
+		}
+		return newFileSink(fileSinkOptions{
+			path:       path,
+			maxSize:    int64(maxSizeMB) * 1024 * 1024,
+			jsonMode:   jsonMode,
+			compress:   cfg.Compress,
+			maxBackups: maxBackups,
+			maxAgeDays: cfg.MaxAgeDays,
+		})
+	case "stderr":
+		return newWriterSink(os.Stderr, jsonMode), nil
+	case "syslog":
+		return newSyslogSink(cfg)
+	case "http":
+		return newHTTPSink(cfg), nil
+	case "elasticsearch":
+		return newElasticsearchSink(cfg), nil
+	case "slack":
+		return newSlackSink(cfg), nil
+	default:
+		return nil, fmt.Errorf("logging: unknown sink type %q", cfg.Type)
+	}
+}
+
+// writerSink renders entries the same way the original single-writer
+// Logger did and writes them to an io.Writer. It's used directly for
+// stderr, and embedded by fileSink for the rotating file case.
+type writerSink struct {
+	w        io.Writer
+	jsonMode bool
+
+	ch   chan Entry
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newWriterSink(w io.Writer, jsonMode bool) *writerSink {
+	s := &writerSink{
+		w:        w,
+		jsonMode: jsonMode,
+		ch:       make(chan Entry, sinkBufferSize),
+		stop:     make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+func (s *writerSink) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case e := <-s.ch:
+			s.write(e)
+		case <-s.stop:
+			s.drain()
+			return
+		}
+	}
+}
+
+func (s *writerSink) drain() {
+	for {
+		select {
+		case e := <-s.ch:
+			s.write(e)
+		default:
+			return
+		}
+	}
+}
+
+func (s *writerSink) write(e Entry) {
+	fmt.Fprintln(s.w, formatEntry(e, s.jsonMode))
+}
+
+func (s *writerSink) Enqueue(e Entry) {
+	select {
+	case s.ch <- e:
+	default:
+		// buffer full: drop rather than block the caller
+	}
+}
+
+func (s *writerSink) Close() error {
+	close(s.stop)
+	s.wg.Wait()
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// formatEntry renders an Entry as either a JSON line or the plain
+// "[ts] [LEVEL] [component] message key=value..." form.
+func formatEntry(e Entry, jsonMode bool) string {
+	if jsonMode {
+		data, _ := json.Marshal(e)
+		return string(data)
+	}
+	return formatPlain(e)
+}
+
+func formatPlain(e Entry) string {
+	ts := e.Timestamp.Format("2006-01-02 15:04:05")
+
+	var result string
+	if e.Component != "" {
+		result = fmt.Sprintf("[%s] [%s] [%s] %s", ts, e.Level, e.Component, e.Message)
+	} else {
+		result = fmt.Sprintf("[%s] [%s] %s", ts, e.Level, e.Message)
+	}
+
+	if len(e.Fields) > 0 {
+		for k, v := range e.Fields {
+			result += fmt.Sprintf(" %s=%v", k, v)
+		}
+	}
+
+	if e.Caller != "" {
+		result += fmt.Sprintf(" (%s)", e.Caller)
+	}
+
+	return result
+}
+
+// fileSink is the original rotating-file sink, now running behind its own
+// buffered channel and flusher goroutine like every other Sink. Rotation
+// is checked from that same goroutine, so a slow rename never blocks the
+// caller's hot logging path.
+type fileSink struct {
+	filePath   string
+	maxSize    int64
+	jsonMode   bool
+	compress   bool
+	maxBackups int
+	maxAgeDays int
+	file       *os.File // nil if falling back to stderr
+
+	ch   chan Entry
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// fileSinkOptions configures a fileSink. Grouped into a struct rather than
+// newFileSink taking five positional parameters now that rotation has
+// compress/retention knobs alongside the original path/size/format ones.
+type fileSinkOptions struct {
+	path       string
+	maxSize    int64
+	jsonMode   bool
+	compress   bool
+	maxBackups int
+	maxAgeDays int
+}
+
+func newFileSink(opts fileSinkOptions) (*fileSink, error) {
+	fs := &fileSink{
+		filePath:   opts.path,
+		maxSize:    opts.maxSize,
+		jsonMode:   opts.jsonMode,
+		compress:   opts.compress,
+		maxBackups: opts.maxBackups,
+		maxAgeDays: opts.maxAgeDays,
+		ch:         make(chan Entry, sinkBufferSize),
+		stop:       make(chan struct{}),
+	}
+
+	if opts.path != "" {
+		fs.openFile() // on failure, fs.file stays nil and we fall back to stderr below
+	}
+
+	fs.wg.Add(1)
+	go fs.run()
+	return fs, nil
+}
+
+func (fs *fileSink) openFile() error {
+	dir := filepath.Dir(fs.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(fs.filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	fs.file = f
+	return nil
+}
+
+func (fs *fileSink) run() {
+	defer fs.wg.Done()
+	for {
+		select {
+		case e := <-fs.ch:
+			fs.writeOne(e)
+		case <-fs.stop:
+			fs.drain()
+			return
+		}
+	}
+}
+
+func (fs *fileSink) drain() {
+	for {
+		select {
+		case e := <-fs.ch:
+			fs.writeOne(e)
+		default:
+			return
+		}
+	}
+}
+
+func (fs *fileSink) writeOne(e Entry) {
+	fs.rotateIfNeeded()
+
+	var w io.Writer = os.Stderr
+	if fs.file != nil {
+		w = fs.file
+	}
+	fmt.Fprintln(w, formatEntry(e, fs.jsonMode))
+}
+
+func (fs *fileSink) Enqueue(e Entry) {
+	select {
+	case fs.ch <- e:
+	default:
+		// buffer full: drop rather than block the caller
+	}
+}
+
+func (fs *fileSink) Close() error {
+	close(fs.stop)
+	fs.wg.Wait()
+	if fs.file != nil {
+		return fs.file.Close()
+	}
+	return nil
+}
+
+// LogPath returns the rotating file's path, or "" if this sink is
+// currently writing to stderr (e.g. because the file couldn't be opened).
+func (fs *fileSink) LogPath() string {
+	if fs.file == nil {
+		return ""
+	}
+	return fs.filePath
+}
@@ -0,0 +1,422 @@
+package logging
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// syslogSeverity maps a wizado Level to its closest RFC5424 severity.
+func syslogSeverity(level string) syslog.Priority {
+	switch level {
+	case "DEBUG":
+		return syslog.LOG_DEBUG
+	case "INFO":
+		return syslog.LOG_INFO
+	case "WARN":
+		return syslog.LOG_WARNING
+	case "ERROR":
+		return syslog.LOG_ERR
+	default:
+		return syslog.LOG_NOTICE
+	}
+}
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern": syslog.LOG_KERN, "user": syslog.LOG_USER, "mail": syslog.LOG_MAIL,
+	"daemon": syslog.LOG_DAEMON, "auth": syslog.LOG_AUTH, "syslog": syslog.LOG_SYSLOG,
+	"local0": syslog.LOG_LOCAL0, "local1": syslog.LOG_LOCAL1, "local2": syslog.LOG_LOCAL2,
+	"local3": syslog.LOG_LOCAL3, "local4": syslog.LOG_LOCAL4, "local5": syslog.LOG_LOCAL5,
+	"local6": syslog.LOG_LOCAL6, "local7": syslog.LOG_LOCAL7,
+}
+
+// syslogSink emits RFC5424-formatted messages, either to the local syslog
+// socket (via the stdlib log/syslog package) or to a remote collector over
+// UDP, TCP, or TLS - log/syslog only supports the former, so the remote
+// case is hand-rolled.
+type syslogSink struct {
+	ch   chan Entry
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	tag      string
+	facility syslog.Priority
+
+	local  *syslog.Writer // set when cfg.Network == ""
+	remote net.Conn       // set when cfg.Network is udp/tcp/tls
+	hostname string
+}
+
+func newSyslogSink(cfg SinkConfig) (*syslogSink, error) {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "wizado"
+	}
+	facility := syslog.LOG_USER
+	if f, ok := syslogFacilities[cfg.Facility]; ok {
+		facility = f
+	}
+
+	s := &syslogSink{
+		ch:       make(chan Entry, sinkBufferSize),
+		stop:     make(chan struct{}),
+		tag:      tag,
+		facility: facility,
+	}
+	s.hostname, _ = os.Hostname()
+
+	if cfg.Network == "" {
+		w, err := syslog.New(facility|syslog.LOG_INFO, tag)
+		if err != nil {
+			return nil, fmt.Errorf("logging: connecting to local syslog: %w", err)
+		}
+		s.local = w
+	} else {
+		conn, err := dialSyslog(cfg.Network, cfg.Address)
+		if err != nil {
+			return nil, fmt.Errorf("logging: dialing syslog at %s://%s: %w", cfg.Network, cfg.Address, err)
+		}
+		s.remote = conn
+	}
+
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+func dialSyslog(network, address string) (net.Conn, error) {
+	switch network {
+	case "tls":
+		return tls.Dial("tcp", address, nil)
+	case "tcp", "udp":
+		return net.Dial(network, address)
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q (want udp, tcp, or tls)", network)
+	}
+}
+
+func (s *syslogSink) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case e := <-s.ch:
+			s.writeOne(e)
+		case <-s.stop:
+			s.drain()
+			return
+		}
+	}
+}
+
+func (s *syslogSink) drain() {
+	for {
+		select {
+		case e := <-s.ch:
+			s.writeOne(e)
+		default:
+			return
+		}
+	}
+}
+
+func (s *syslogSink) writeOne(e Entry) {
+	msg := formatPlain(e)
+
+	if s.local != nil {
+		switch e.Level {
+		case "DEBUG":
+			s.local.Debug(msg)
+		case "WARN":
+			s.local.Warning(msg)
+		case "ERROR":
+			s.local.Err(msg)
+		default:
+			s.local.Info(msg)
+		}
+		return
+	}
+
+	if s.remote == nil {
+		return
+	}
+	pri := s.facility | syslogSeverity(e.Level)
+	// RFC5424: <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID MSG
+	frame := fmt.Sprintf("<%d>1 %s %s %s %d - %s\n",
+		pri, e.Timestamp.Format(time.RFC3339), s.hostname, s.tag, os.Getpid(), msg)
+	s.remote.Write([]byte(frame))
+}
+
+func (s *syslogSink) Enqueue(e Entry) {
+	select {
+	case s.ch <- e:
+	default:
+	}
+}
+
+func (s *syslogSink) Close() error {
+	close(s.stop)
+	s.wg.Wait()
+	if s.local != nil {
+		return s.local.Close()
+	}
+	if s.remote != nil {
+		return s.remote.Close()
+	}
+	return nil
+}
+
+// batchSink is shared scaffolding for sinks that POST batches of JSON to an
+// HTTP endpoint (plain HTTP, Elasticsearch bulk) with retry/backoff.
+type batchSink struct {
+	ch   chan Entry
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	client      *http.Client
+	endpoint    string
+	headers     map[string]string
+	batchSize   int
+	flushEvery  time.Duration
+	maxRetries  int
+
+	encode func(batch []Entry) ([]byte, string) // returns body and content-type
+}
+
+func newBatchSink(cfg SinkConfig, encode func([]Entry) ([]byte, string)) *batchSink {
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+	flushMillis := cfg.FlushMillis
+	if flushMillis <= 0 {
+		flushMillis = 5000
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+
+	b := &batchSink{
+		ch:         make(chan Entry, sinkBufferSize),
+		stop:       make(chan struct{}),
+		client:     &http.Client{Timeout: 10 * time.Second},
+		endpoint:   cfg.Endpoint,
+		headers:    cfg.Headers,
+		batchSize:  batchSize,
+		flushEvery: time.Duration(flushMillis) * time.Millisecond,
+		maxRetries: maxRetries,
+		encode:     encode,
+	}
+	b.wg.Add(1)
+	go b.run()
+	return b
+}
+
+func (b *batchSink) run() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.flushEvery)
+	defer ticker.Stop()
+
+	var pending []Entry
+	for {
+		select {
+		case e := <-b.ch:
+			pending = append(pending, e)
+			if len(pending) >= b.batchSize {
+				b.send(pending)
+				pending = nil
+			}
+		case <-ticker.C:
+			if len(pending) > 0 {
+				b.send(pending)
+				pending = nil
+			}
+		case <-b.stop:
+			b.drain(&pending)
+			if len(pending) > 0 {
+				b.send(pending)
+			}
+			return
+		}
+	}
+}
+
+func (b *batchSink) drain(pending *[]Entry) {
+	for {
+		select {
+		case e := <-b.ch:
+			*pending = append(*pending, e)
+		default:
+			return
+		}
+	}
+}
+
+// send posts one batch, retrying with exponential backoff on failure.
+// Errors are swallowed after the last retry - a down collector must never
+// take the logging pipeline down with it.
+func (b *batchSink) send(batch []Entry) {
+	body, contentType := b.encode(batch)
+
+	backoff := 200 * time.Millisecond
+	for attempt := 0; attempt <= b.maxRetries; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, b.endpoint, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", contentType)
+			for k, v := range b.headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := b.client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+				if resp.StatusCode < 500 {
+					return
+				}
+			}
+		}
+
+		if attempt < b.maxRetries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+func (b *batchSink) Enqueue(e Entry) {
+	select {
+	case b.ch <- e:
+	default:
+	}
+}
+
+func (b *batchSink) Close() error {
+	close(b.stop)
+	b.wg.Wait()
+	return nil
+}
+
+// newHTTPSink batches entries as a JSON array and POSTs them to cfg.Endpoint.
+func newHTTPSink(cfg SinkConfig) *batchSink {
+	return newBatchSink(cfg, func(batch []Entry) ([]byte, string) {
+		data, _ := json.Marshal(batch)
+		return data, "application/json"
+	})
+}
+
+// newElasticsearchSink batches entries using the Elasticsearch bulk API's
+// newline-delimited JSON format: an index action line followed by the
+// document line, repeated per entry.
+func newElasticsearchSink(cfg SinkConfig) *batchSink {
+	index := cfg.Index
+	if index == "" {
+		index = "wizado-logs"
+	}
+	return newBatchSink(cfg, func(batch []Entry) ([]byte, string) {
+		var buf bytes.Buffer
+		for _, e := range batch {
+			action, _ := json.Marshal(map[string]any{
+				"index": map[string]any{"_index": index},
+			})
+			buf.Write(action)
+			buf.WriteByte('\n')
+			doc, _ := json.Marshal(e)
+			buf.Write(doc)
+			buf.WriteByte('\n')
+		}
+		return buf.Bytes(), "application/x-ndjson"
+	})
+}
+
+// slackSink posts a message to an incoming webhook URL, but only for
+// entries at LevelError - it's an alerting channel, not a log stream.
+type slackSink struct {
+	ch   chan Entry
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	client     *http.Client
+	webhookURL string
+}
+
+func newSlackSink(cfg SinkConfig) *slackSink {
+	s := &slackSink{
+		ch:         make(chan Entry, sinkBufferSize),
+		stop:       make(chan struct{}),
+		client:     &http.Client{Timeout: 10 * time.Second},
+		webhookURL: cfg.WebhookURL,
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+func (s *slackSink) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case e := <-s.ch:
+			s.writeOne(e)
+		case <-s.stop:
+			s.drain()
+			return
+		}
+	}
+}
+
+func (s *slackSink) drain() {
+	for {
+		select {
+		case e := <-s.ch:
+			s.writeOne(e)
+		default:
+			return
+		}
+	}
+}
+
+func (s *slackSink) writeOne(e Entry) {
+	if e.Level != "ERROR" || s.webhookURL == "" {
+		return
+	}
+
+	text := fmt.Sprintf(":rotating_light: *%s* %s", e.Component, e.Message)
+	if len(e.Fields) > 0 {
+		var parts []string
+		for k, v := range e.Fields {
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+		}
+		text += " `" + strings.Join(parts, " ") + "`"
+	}
+
+	payload, _ := json.Marshal(map[string]string{"text": text})
+	resp, err := s.client.Post(s.webhookURL, "application/json", bytes.NewReader(payload))
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+
+func (s *slackSink) Enqueue(e Entry) {
+	if e.Level != "ERROR" {
+		return
+	}
+	select {
+	case s.ch <- e:
+	default:
+	}
+}
+
+func (s *slackSink) Close() error {
+	close(s.stop)
+	s.wg.Wait()
+	return nil
+}
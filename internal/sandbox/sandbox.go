@@ -0,0 +1,118 @@
+// Package sandbox isolates Steam and games under a dedicated unprivileged
+// Linux user, granting it access to just the Wayland/audio sockets and
+// Steam library paths it needs via POSIX ACLs instead of running as the
+// desktop user outright.
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+
+	"github.com/wattfource/wizado/internal/logging"
+)
+
+// DefaultUser is the sandbox account wizado provisions when none is configured.
+const DefaultUser = "wizado-play"
+
+var log *logging.Logger
+
+func init() {
+	log = logging.WithComponent("sandbox")
+}
+
+// Sandbox tracks the ACL grants made for one gaming session so they can be
+// revoked when the session ends.
+type Sandbox struct {
+	User  string
+	paths []string
+}
+
+// EnsureUser creates the unprivileged sandbox user if it doesn't already
+// exist. It has no home directory and no login shell - it only ever runs
+// Steam/gamescope, launched directly via runuser.
+func EnsureUser(username string) error {
+	if _, err := user.Lookup(username); err == nil {
+		return nil
+	}
+
+	if _, err := exec.LookPath("useradd"); err != nil {
+		return fmt.Errorf("useradd not found, cannot provision sandbox user %s", username)
+	}
+
+	log.Infof("Provisioning sandbox user %s", username)
+	return exec.Command("useradd", "--system", "--no-create-home", "--shell", "/usr/sbin/nologin", username).Run()
+}
+
+// Prepare grants username read/write ACL access to the running Wayland
+// socket, the PulseAudio/PipeWire sockets, and any extra paths (Steam
+// library directories, save data, ...) for the duration of one session.
+// Call Cleanup when the session ends to revoke everything it granted.
+func Prepare(username string, extraPaths ...string) (*Sandbox, error) {
+	sb := &Sandbox{User: username}
+
+	if !aclAvailable() {
+		log.Warn("setfacl not found, sandbox ACLs will not be granted")
+		return sb, nil
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	var candidates []string
+	if runtimeDir != "" {
+		if matches, _ := filepath.Glob(filepath.Join(runtimeDir, "wayland-*")); len(matches) > 0 {
+			candidates = append(candidates, matches...)
+		}
+		candidates = append(candidates,
+			filepath.Join(runtimeDir, "pulse", "native"),
+			filepath.Join(runtimeDir, "pipewire-0"),
+		)
+	}
+	candidates = append(candidates, extraPaths...)
+
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := exec.Command("setfacl", "-m", "u:"+username+":rwx", path).Run(); err != nil {
+			log.Warnf("Could not grant %s access to %s: %v", username, path, err)
+			continue
+		}
+		sb.paths = append(sb.paths, path)
+	}
+
+	log.Infof("Granted sandbox user %s access to %d paths", username, len(sb.paths))
+	return sb, nil
+}
+
+// Paths returns the filesystem paths Prepare granted username access to.
+func (sb *Sandbox) Paths() []string {
+	return sb.paths
+}
+
+// Cleanup revokes every ACL entry Prepare granted and reaps any processes
+// left running as the sandbox user.
+func (sb *Sandbox) Cleanup() {
+	for _, path := range sb.paths {
+		if err := exec.Command("setfacl", "-x", "u:"+sb.User, path).Run(); err != nil {
+			log.Debugf("Could not revoke %s access to %s: %v", sb.User, path, err)
+		}
+	}
+	ReapProcesses(sb.User)
+}
+
+// ReapProcesses kills any leftover processes owned by username. Used both
+// after a normal session and from `wizado remove` to recover a sandbox left
+// behind by a crash that skipped Cleanup.
+func ReapProcesses(username string) error {
+	if _, err := user.Lookup(username); err != nil {
+		return nil
+	}
+	return exec.Command("pkill", "-9", "-u", username).Run()
+}
+
+func aclAvailable() bool {
+	_, err := exec.LookPath("setfacl")
+	return err == nil
+}
@@ -0,0 +1,238 @@
+package setup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hyprPerfConfigPath is the self-contained Hyprland performance fragment
+// wizado owns outright, sourced from wizado.conf rather than spliced into
+// the user's main config.
+func hyprPerfConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "hypr", "wizado-perf.conf")
+}
+
+// mangoHudConfigPath is the MangoHUD config wizado owns, read automatically
+// by MangoHUD since it lives in its default per-app-override search path.
+func mangoHudConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "MangoHud", "wizado.conf")
+}
+
+// hyprPerfSourceLine is the line desiredHyprConfig adds to wizado.conf so
+// the perf fragment is picked up without a second line in the user's own
+// config - configureKeybindings already owns the one source line Run adds
+// there.
+const hyprPerfSourceLine = "source = ~/.config/hypr/wizado-perf.conf"
+
+// hyprMonitor is the subset of `hyprctl monitors -j` fields that feed into
+// the per-monitor tuning decisions below.
+type hyprMonitor struct {
+	Name        string  `json:"name"`
+	RefreshRate float64 `json:"refreshRate"`
+	Vrr         bool    `json:"vrr"`
+	Hdr         bool    `json:"hdr"`
+	Focused     bool    `json:"focused"`
+}
+
+// detectPrimaryMonitor runs `hyprctl monitors -j` and returns the focused
+// monitor, or the first one if none report focus.
+func detectPrimaryMonitor() (hyprMonitor, error) {
+	out, err := exec.Command("hyprctl", "monitors", "-j").Output()
+	if err != nil {
+		return hyprMonitor{}, fmt.Errorf("hyprctl monitors: %w", err)
+	}
+
+	var monitors []hyprMonitor
+	if err := json.Unmarshal(out, &monitors); err != nil {
+		return hyprMonitor{}, fmt.Errorf("parsing hyprctl monitors output: %w", err)
+	}
+	if len(monitors) == 0 {
+		return hyprMonitor{}, fmt.Errorf("no monitors reported by hyprctl")
+	}
+
+	for _, m := range monitors {
+		if m.Focused {
+			return m, nil
+		}
+	}
+	return monitors[0], nil
+}
+
+// gamescopeSupportsHDR reports whether the installed gamescope is new
+// enough (>= 3.15) to pass ENABLE_HDR_WSI through to games reliably.
+func gamescopeSupportsHDR() bool {
+	out, err := exec.Command("gamescope", "--version").Output()
+	if err != nil {
+		return false
+	}
+	return versionAtLeast(string(out), 3, 15)
+}
+
+// versionAtLeast reports whether the first "major.minor" version number
+// found in s is >= the given major.minor.
+func versionAtLeast(s string, wantMajor, wantMinor int) bool {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	})
+	for _, f := range fields {
+		parts := strings.SplitN(f, ".", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		major, err1 := strconv.Atoi(parts[0])
+		minor, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		return major > wantMajor || (major == wantMajor && minor >= wantMinor)
+	}
+	return false
+}
+
+// compositorTuning is the result of deciding how to tune Hyprland/gamescope
+// for one monitor on one GPU.
+type compositorTuning struct {
+	Monitor         hyprMonitor
+	VRR             bool
+	ExplicitSync    int // Hyprland's render:explicit_sync: 0 off, 1 on
+	HDR             bool
+	ColorManagement bool
+	PresentMode     string // "immediate" or "mailbox"
+	MangoHudFPSCap  int    // 0 means no cap
+}
+
+// buildCompositorTuning turns detected hardware/monitor capabilities into
+// concrete Hyprland/gamescope/MangoHUD settings.
+//
+// explicit_sync is only trustworthy on NVIDIA 555+ (earlier drivers have
+// known render corruption with it on); AMD's amdgpu has supported it since
+// well before any driver version wizado targets, so it's always left on
+// there. High refresh monitors favor mailbox (buffered, no tearing) over
+// immediate (lower latency, can tear) since the margin for tearing to be
+// visible shrinks as refresh rises.
+func buildCompositorTuning(gpu GPUInfo, mon hyprMonitor) compositorTuning {
+	t := compositorTuning{Monitor: mon, VRR: mon.Vrr}
+
+	switch {
+	case gpu.HasNVIDIA:
+		t.ExplicitSync = boolToInt(versionAtLeast(gpu.NVIDIADriverVersion, 555, 0))
+	case gpu.HasAMD:
+		t.ExplicitSync = 1
+	default:
+		t.ExplicitSync = 1
+	}
+
+	if mon.Hdr && gamescopeSupportsHDR() {
+		t.HDR = true
+		t.ColorManagement = true
+	}
+
+	if mon.RefreshRate >= 120 {
+		t.PresentMode = "mailbox"
+	} else {
+		t.PresentMode = "immediate"
+	}
+
+	if t.VRR && mon.RefreshRate > 3 {
+		t.MangoHudFPSCap = int(mon.RefreshRate) - 3
+	}
+
+	return t
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// desiredPerfConfig renders the Hyprland fragment wizado owns for
+// compositor tuning: VRR, explicit sync, and HDR/color management opt-ins
+// for the detected monitor and GPU.
+func desiredPerfConfig(t compositorTuning) string {
+	var b strings.Builder
+	b.WriteString("# Managed by wizado setup - do not edit by hand.\n")
+	b.WriteString(fmt.Sprintf("# Tuned for monitor %q (refresh %.0fHz, vrr=%v, hdr=%v).\n", t.Monitor.Name, t.Monitor.RefreshRate, t.Monitor.Vrr, t.Monitor.Hdr))
+	b.WriteString("# Run `wizado setup --uninstall` to remove this file and its include line.\n\n")
+
+	vrr := 0
+	if t.VRR {
+		vrr = 2 // per-monitor VRR, enabled only when the display reports the capability
+	}
+	b.WriteString(fmt.Sprintf("misc:vrr = %d\n", vrr))
+	b.WriteString(fmt.Sprintf("render:explicit_sync = %d\n", t.ExplicitSync))
+
+	if t.HDR {
+		b.WriteString("experimental:xx_color_management_v4 = true\n")
+		b.WriteString("env = ENABLE_HDR_WSI,1\n")
+	}
+
+	return b.String()
+}
+
+// desiredMangoHudConfig renders a MangoHUD config capping the overlay's FPS
+// limit just under the monitor's refresh rate on VRR displays, so frame
+// pacing stays inside the VRR window instead of bouncing against its edge.
+func desiredMangoHudConfig(t compositorTuning) string {
+	var b strings.Builder
+	b.WriteString("# Managed by wizado setup - do not edit by hand.\n")
+	if t.MangoHudFPSCap > 0 {
+		b.WriteString(fmt.Sprintf("fps_limit=%d\n", t.MangoHudFPSCap))
+	}
+	return b.String()
+}
+
+// configureCompositor tunes Hyprland/MangoHUD for the detected GPU and
+// primary monitor: VRR, explicit sync, HDR/color management, and a
+// VRR-aware MangoHUD FPS cap. It runs before configureKeybindings so the
+// wizado.conf fragment that sources it already has something to source.
+func configureCompositor(gpu GPUInfo, opts Options) error {
+	if _, err := exec.LookPath("hyprctl"); err != nil {
+		fmt.Println("hyprctl not found - skipping compositor tuning")
+		return nil
+	}
+
+	mon, err := detectPrimaryMonitor()
+	if err != nil {
+		return fmt.Errorf("detecting monitor capabilities: %w", err)
+	}
+
+	tuning := buildCompositorTuning(gpu, mon)
+	perfPath := hyprPerfConfigPath()
+	mangoPath := mangoHudConfigPath()
+
+	if opts.DryRun {
+		fmt.Printf("[DRY RUN] Would tune compositor for %q (vrr=%v, explicit_sync=%d, hdr=%v)\n",
+			mon.Name, tuning.VRR, tuning.ExplicitSync, tuning.HDR)
+		return nil
+	}
+
+	if err := opts.tx.BackupFile(perfPath); err != nil {
+		return err
+	}
+	perfChanged, err := writeIfChanged(perfPath, desiredPerfConfig(tuning))
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", perfPath, err)
+	}
+
+	if err := opts.tx.BackupFile(mangoPath); err != nil {
+		return err
+	}
+	if _, err := writeIfChanged(mangoPath, desiredMangoHudConfig(tuning)); err != nil {
+		return fmt.Errorf("writing %s: %w", mangoPath, err)
+	}
+
+	if perfChanged {
+		fmt.Printf("✓ Tuned compositor for %s (vrr=%v, explicit_sync=%d, hdr=%v)\n", mon.Name, tuning.VRR, tuning.ExplicitSync, tuning.HDR)
+		log.Infof("Compositor tuned for monitor %s: vrr=%v explicit_sync=%d hdr=%v", mon.Name, tuning.VRR, tuning.ExplicitSync, tuning.HDR)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// NixProvisioner targets NixOS systems. Rather than installing packages and
+// editing live config files the way ArchProvisioner does, it emits
+// declarative Nix modules for the user to import into their system
+// configuration and Home-Manager setup.
+type NixProvisioner struct{}
+
+func (NixProvisioner) Name() string { return "nixos" }
+
+// Prepare is a no-op on NixOS - there's no PackageManager to resolve, since
+// nothing here shells out to install anything.
+func (NixProvisioner) Prepare(opts Options) (Options, error) {
+	return opts, nil
+}
+
+// nixConfigDir is where generated modules/fragments are written, for the
+// user to import from their own configuration.
+func nixConfigDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "wizado")
+}
+
+func writeNixFile(opts Options, name, content, label string) error {
+	path := filepath.Join(nixConfigDir(), name)
+
+	if opts.DryRun {
+		fmt.Printf("[DRY RUN] Would write %s to %s\n", label, path)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Wrote %s: %s\n", label, path)
+	log.Infof("%s written: %s", label, path)
+	return nil
+}
+
+// EnsureRepos is a no-op on NixOS - the multilib equivalent is just
+// hardware.opengl.driSupport32Bit, which InstallDependencies already writes
+// into wizado.nix.
+func (NixProvisioner) EnsureRepos(opts Options) error {
+	return nil
+}
+
+// InstallDependencies doesn't install anything directly; it writes a
+// wizado.nix system module declaring Steam, GameMode, gamescope, and 32-bit
+// OpenGL support, for the user to import from configuration.nix.
+func (NixProvisioner) InstallDependencies(gpu GPUInfo, opts Options) error {
+	var gpuOptions strings.Builder
+	if gpu.HasNVIDIA {
+		gpuOptions.WriteString("  services.xserver.videoDrivers = [ \"nvidia\" ];\n")
+		gpuOptions.WriteString("  hardware.nvidia.modesetting.enable = true;\n")
+	}
+	if gpu.HasAMD {
+		gpuOptions.WriteString("  hardware.amdgpu.opencl.enable = true;\n")
+	}
+
+	module := fmt.Sprintf(`{ config, pkgs, ... }:
+
+# Generated by `+"`wizado setup`"+` - import this from your configuration.nix.
+{
+  programs.steam.enable = true;
+  programs.gamemode.enable = true;
+  programs.gamescope = {
+    enable = true;
+    capSysNice = true;
+  };
+
+  hardware.opengl = {
+    enable = true;
+    driSupport32Bit = true;
+  };
+
+%s
+  environment.systemPackages = with pkgs; [
+    mangohud
+    jq
+  ];
+}
+`, gpuOptions.String())
+
+	return writeNixFile(opts, "wizado.nix", module, "NixOS module")
+}
+
+// InstallOptionalPackages is a no-op beyond what InstallDependencies already
+// declared: GameMode and MangoHUD are nixpkgs packages and already in
+// wizado.nix. The AUR-only extras ArchProvisioner offers (gamescope-git,
+// proton-ge-custom-bin) have no nixpkgs equivalent, so they're just called
+// out rather than silently skipped.
+func (NixProvisioner) InstallOptionalPackages(opts Options) error {
+	fmt.Println("✓ Optional packages (GameMode, MangoHUD) already declared in wizado.nix")
+	fmt.Println("  gamescope-git/proton-ge-custom-bin have no nixpkgs equivalent;")
+	fmt.Println("  consider an overlay (e.g. chaotic-nyx) if you want them.")
+	return nil
+}
+
+// ConfigureCompositor queries the live Hyprland compositor for monitor
+// capabilities (NixOS still runs hyprctl against a real compositor, even
+// though the rest of the config is declarative) and writes a Home-Manager
+// fragment with the resulting VRR/explicit-sync/HDR tuning, instead of
+// editing ~/.config/hypr files directly.
+func (NixProvisioner) ConfigureCompositor(gpu GPUInfo, opts Options) error {
+	if _, err := exec.LookPath("hyprctl"); err != nil {
+		fmt.Println("hyprctl not found - skipping compositor tuning")
+		return nil
+	}
+
+	mon, err := detectPrimaryMonitor()
+	if err != nil {
+		return fmt.Errorf("detecting monitor capabilities: %w", err)
+	}
+	t := buildCompositorTuning(gpu, mon)
+
+	vrr := 0
+	if t.VRR {
+		vrr = 2
+	}
+
+	var extra strings.Builder
+	if t.HDR {
+		extra.WriteString("      \"experimental:xx_color_management_v4\" = true;\n")
+		extra.WriteString("      env = \"ENABLE_HDR_WSI,1\";\n")
+	}
+
+	fragment := fmt.Sprintf(`{ config, ... }:
+
+# Tuned for monitor %q (refresh %.0fHz, vrr=%v, hdr=%v).
+{
+  wayland.windowManager.hyprland.settings = {
+    misc."vrr" = %d;
+    render."explicit_sync" = %d;
+%s  };
+}
+`, mon.Name, mon.RefreshRate, mon.Vrr, mon.Hdr, vrr, t.ExplicitSync, extra.String())
+
+	return writeNixFile(opts, "wizado-compositor.nix", fragment, "Home-Manager compositor tuning fragment")
+}
+
+// ConfigureKeybindings writes a Home-Manager fragment binding the Wizado
+// menu and kill-Steam shortcuts, instead of editing hyprland.conf directly.
+func (NixProvisioner) ConfigureKeybindings(opts Options) error {
+	fragment := `{ config, ... }:
+
+{
+  wayland.windowManager.hyprland.settings.bind = [
+    "SUPER SHIFT, S, exec, wizado-menu-float"
+    "SUPER SHIFT, Q, exec, pkill -9 steam; pkill -9 gamescope"
+  ];
+}
+`
+	return writeNixFile(opts, "wizado-keybindings.nix", fragment, "Home-Manager keybindings fragment")
+}
+
+// ConfigureWaybar writes a Home-Manager fragment declaring the wizado
+// Waybar module, instead of patching config.jsonc in place.
+func (NixProvisioner) ConfigureWaybar(opts Options) error {
+	fragment := `{ config, ... }:
+
+{
+  programs.waybar.settings.mainBar."custom/wizado" = {
+    format = "{}";
+    "return-type" = "json";
+    exec = "wizado status";
+    "on-click" = "wizado-menu-float";
+    "on-click-right" = "wizado-menu-float";
+    interval = 60;
+    tooltip = true;
+  };
+}
+`
+	return writeNixFile(opts, "wizado-waybar.nix", fragment, "Home-Manager waybar fragment")
+}
@@ -0,0 +1,192 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// PackageManager abstracts how packages get installed so setup isn't
+// hard-wired to `sudo pacman -S`. This is what lets installDependencies and
+// installOptionalPackages reach AUR-only packages - gamescope-git,
+// mangohud-git, proton-ge-custom-bin - through whichever AUR helper is
+// present, while falling back to plain pacman when one isn't.
+type PackageManager interface {
+	// Name identifies the manager for log/progress messages, e.g. "pacman"
+	// or "paru".
+	Name() string
+
+	// Install installs pkgs, prompting for privilege escalation as needed.
+	Install(pkgs []string) error
+
+	// Query reports whether pkg is installed and, if so, its version.
+	Query(pkg string) (installed bool, version string, err error)
+
+	// Remove uninstalls pkgs.
+	Remove(pkgs []string) error
+
+	// AddRepo enables the named repository section in /etc/pacman.conf
+	// (e.g. "multilib") and refreshes the package database.
+	AddRepo(name string) error
+}
+
+// DetectPackageManager auto-selects a paru or yay AUR helper if one is on
+// PATH, falling back to plain pacman. AUR helpers are preferred since they
+// can install both official and AUR packages through one interface, which
+// plain pacman can't.
+func DetectPackageManager() PackageManager {
+	for _, bin := range []string{"paru", "yay"} {
+		if _, err := exec.LookPath(bin); err == nil {
+			return newAURHelperManager(bin)
+		}
+	}
+	return pacmanManager{}
+}
+
+// pacmanManager drives pacman directly via sudo - the only option on a
+// system with no AUR helper installed, and also what AddRepo/Remove fall
+// back to even when an AUR helper is in use, since those operations don't
+// benefit from one.
+type pacmanManager struct{}
+
+func (pacmanManager) Name() string { return "pacman" }
+
+func (pacmanManager) Install(pkgs []string) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+	args := append([]string{"pacman", "-S", "--needed", "--noconfirm"}, pkgs...)
+	cmd := exec.Command("sudo", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (pacmanManager) Query(pkg string) (bool, string, error) {
+	out, err := exec.Command("pacman", "-Qi", pkg).Output()
+	if err != nil {
+		return false, "", nil // not installed, not an error
+	}
+	version := ""
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "Version") {
+			if idx := strings.Index(line, ":"); idx != -1 {
+				version = strings.TrimSpace(line[idx+1:])
+			}
+			break
+		}
+	}
+	return true, version, nil
+}
+
+func (pacmanManager) Remove(pkgs []string) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+	args := append([]string{"pacman", "-R", "--noconfirm"}, pkgs...)
+	cmd := exec.Command("sudo", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (pacmanManager) AddRepo(name string) error {
+	data, err := os.ReadFile("/etc/pacman.conf")
+	if err != nil {
+		return err
+	}
+
+	section := "[" + name + "]"
+	if strings.Contains(string(data), section) && !strings.Contains(string(data), "#"+section) {
+		return nil // already enabled
+	}
+
+	cmd := exec.Command("sudo", "sed", "-i",
+		fmt.Sprintf("/^#\\%s/,/^#Include/ s/^#//", section), "/etc/pacman.conf")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to enable %s: %v", name, err)
+	}
+
+	fmt.Println("Refreshing package database...")
+	cmd = exec.Command("sudo", "pacman", "-Syy")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to refresh package database: %v", err)
+	}
+	return nil
+}
+
+// aurHelperManager drives an AUR helper (paru or yay) for installs, which
+// transparently handles both official-repo and AUR packages, and delegates
+// everything else to pacmanManager since neither helper adds anything for
+// query/remove/repo management.
+type aurHelperManager struct {
+	bin    string
+	pacman pacmanManager
+}
+
+func newAURHelperManager(bin string) *aurHelperManager {
+	return &aurHelperManager{bin: bin}
+}
+
+func (a *aurHelperManager) Name() string { return a.bin }
+
+func (a *aurHelperManager) Install(pkgs []string) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+	args := append([]string{"-S", "--needed", "--noconfirm"}, pkgs...)
+	cmd := exec.Command(a.bin, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (a *aurHelperManager) Query(pkg string) (bool, string, error) {
+	return a.pacman.Query(pkg)
+}
+
+func (a *aurHelperManager) Remove(pkgs []string) error {
+	return a.pacman.Remove(pkgs)
+}
+
+func (a *aurHelperManager) AddRepo(name string) error {
+	return a.pacman.AddRepo(name)
+}
+
+// dryRunPackageManager decorates another PackageManager so mutating calls
+// only print what they would have done. Query passes through unchanged -
+// it's read-only, and dry-run callers still need a real answer to decide
+// what they *would* install.
+type dryRunPackageManager struct {
+	inner PackageManager
+}
+
+func (d dryRunPackageManager) Name() string { return d.inner.Name() + " (dry run)" }
+
+func (d dryRunPackageManager) Install(pkgs []string) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+	fmt.Printf("[DRY RUN] Would install via %s: %s\n", d.inner.Name(), strings.Join(pkgs, ", "))
+	return nil
+}
+
+func (d dryRunPackageManager) Query(pkg string) (bool, string, error) {
+	return d.inner.Query(pkg)
+}
+
+func (d dryRunPackageManager) Remove(pkgs []string) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+	fmt.Printf("[DRY RUN] Would remove via %s: %s\n", d.inner.Name(), strings.Join(pkgs, ", "))
+	return nil
+}
+
+func (d dryRunPackageManager) AddRepo(name string) error {
+	fmt.Printf("[DRY RUN] Would enable [%s] repo in /etc/pacman.conf\n", name)
+	return nil
+}
@@ -0,0 +1,89 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Provisioner performs the distro-specific steps of Run: enabling repos,
+// installing dependencies, and wiring up Hyprland/Waybar integration. This
+// is what lets Run target both Arch (imperative package installs, live
+// config edits) and NixOS (declarative modules) from one setup flow.
+type Provisioner interface {
+	// Name identifies the provisioner for log/progress messages, e.g.
+	// "arch" or "nixos".
+	Name() string
+
+	// Prepare finishes resolving any provisioner-specific options (e.g.
+	// ArchProvisioner's PackageManager) and returns the possibly-updated
+	// Options.
+	Prepare(opts Options) (Options, error)
+
+	EnsureRepos(opts Options) error
+	InstallDependencies(gpu GPUInfo, opts Options) error
+	InstallOptionalPackages(opts Options) error
+	ConfigureCompositor(gpu GPUInfo, opts Options) error
+	ConfigureKeybindings(opts Options) error
+	ConfigureWaybar(opts Options) error
+}
+
+// DetectProvisioner auto-selects NixProvisioner on a NixOS system (/etc/NIXOS
+// present, or nixos-rebuild on PATH) and falls back to ArchProvisioner
+// otherwise.
+func DetectProvisioner() Provisioner {
+	if _, err := os.Stat("/etc/NIXOS"); err == nil {
+		return NixProvisioner{}
+	}
+	if _, err := exec.LookPath("nixos-rebuild"); err == nil {
+		return NixProvisioner{}
+	}
+	return ArchProvisioner{}
+}
+
+// ArchProvisioner is the original pacman/AUR-based setup flow: it just
+// delegates to the free functions that implemented each step before
+// Provisioner existed.
+type ArchProvisioner struct{}
+
+func (ArchProvisioner) Name() string { return "arch" }
+
+// Prepare auto-selects a PackageManager (unless the caller already set one)
+// and wraps it for dry-run. This used to happen unconditionally at the top
+// of Run, but NixProvisioner has no PackageManager concept at all, so it
+// moved here.
+func (ArchProvisioner) Prepare(opts Options) (Options, error) {
+	if opts.PackageManager == nil {
+		opts.PackageManager = DetectPackageManager()
+	}
+	if opts.DryRun {
+		opts.PackageManager = dryRunPackageManager{inner: opts.PackageManager}
+	}
+	fmt.Printf("Using package manager: %s\n", opts.PackageManager.Name())
+	log.Infof("Package manager: %s", opts.PackageManager.Name())
+	return opts, nil
+}
+
+func (ArchProvisioner) EnsureRepos(opts Options) error {
+	return ensureMultilib(opts)
+}
+
+func (ArchProvisioner) InstallDependencies(gpu GPUInfo, opts Options) error {
+	return installDependencies(gpu, opts)
+}
+
+func (ArchProvisioner) InstallOptionalPackages(opts Options) error {
+	return installOptionalPackages(opts)
+}
+
+func (ArchProvisioner) ConfigureCompositor(gpu GPUInfo, opts Options) error {
+	return configureCompositor(gpu, opts)
+}
+
+func (ArchProvisioner) ConfigureKeybindings(opts Options) error {
+	return configureKeybindings(opts)
+}
+
+func (ArchProvisioner) ConfigureWaybar(opts Options) error {
+	return configureWaybar(opts)
+}
@@ -0,0 +1,257 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// hyprManagedConfigPath is the self-contained Hyprland fragment wizado
+// owns outright: configureKeybindings only ever overwrites it wholesale,
+// never edits around markers inside it.
+func hyprManagedConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "hypr", "wizado.conf")
+}
+
+// waybarManagedConfigPath is the self-contained waybar module fragment
+// wizado owns outright, included from the user's waybar config rather than
+// spliced into it.
+func waybarManagedConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "waybar", "wizado.jsonc")
+}
+
+// hyprSourceLine is the line configureKeybindings ensures exists (exactly
+// once) in the user's Hyprland config.
+const hyprSourceLine = "source = ~/.config/hypr/wizado.conf"
+
+// findHyprMainConfig locates the Hyprland config wizado should source its
+// managed fragment from - whichever of the usual bindings/keybinds/main
+// config files exists.
+func findHyprMainConfig() (string, error) {
+	home, _ := os.UserHomeDir()
+	candidates := []string{
+		filepath.Join(home, ".config", "hypr", "bindings.conf"),
+		filepath.Join(home, ".config", "hypr", "keybinds.conf"),
+		filepath.Join(home, ".config", "hypr", "hyprland.conf"),
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("could not find Hyprland config")
+}
+
+// findWaybarMainConfig locates the waybar config wizado should include its
+// managed module fragment from.
+func findWaybarMainConfig() (string, error) {
+	home, _ := os.UserHomeDir()
+	waybarDir := filepath.Join(home, ".config", "waybar")
+	for _, name := range []string{"config.jsonc", "config"} {
+		path := filepath.Join(waybarDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("waybar config not found")
+}
+
+// desiredHyprConfig renders the Hyprland fragment wizado owns: binds for
+// the menu and kill-Steam shortcuts, in whichever bind style (bind vs
+// bindd) the user's config already uses.
+func desiredHyprConfig(bindStyle string) string {
+	var b strings.Builder
+	b.WriteString("# Managed by wizado setup - do not edit by hand.\n")
+	b.WriteString("# Run `wizado setup --uninstall` to remove this file and its include line.\n\n")
+	if bindStyle == "bind" {
+		b.WriteString("bind = SUPER SHIFT, S, exec, wizado-menu-float\n")
+		b.WriteString("bind = SUPER SHIFT, Q, exec, pkill -9 steam; pkill -9 gamescope\n")
+	} else {
+		b.WriteString("bindd = SUPER SHIFT, S, Wizado Menu, exec, wizado-menu-float\n")
+		b.WriteString("bindd = SUPER SHIFT, Q, Kill Steam, exec, pkill -9 steam; pkill -9 gamescope\n")
+	}
+	b.WriteString("\n" + hyprPerfSourceLine + "\n")
+	return b.String()
+}
+
+// desiredWaybarModule renders the waybar module fragment wizado owns.
+func desiredWaybarModule() string {
+	return `{
+    "custom/wizado": {
+        "format": "{}",
+        "return-type": "json",
+        "exec": "wizado status",
+        "on-click": "wizado-menu-float",
+        "on-click-right": "wizado-menu-float",
+        "interval": 60,
+        "tooltip": true
+    }
+}
+`
+}
+
+// writeIfChanged writes content to path only if it differs from what's
+// already there, so Reconcile can be re-run freely without needless
+// rewrites (or hyprctl/waybar restarts) once the managed state matches.
+func writeIfChanged(path, content string) (changed bool, err error) {
+	if existing, err := os.ReadFile(path); err == nil && string(existing) == content {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ensureSourceLine appends line to the file at path if it isn't already
+// present, so re-running setup never duplicates the include.
+func ensureSourceLine(path, line string) (changed bool, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(existing) == line {
+			return false, nil
+		}
+	}
+	content := string(data)
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += line + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeLine removes any line matching want from the file at path. It's a
+// no-op, not an error, if the file doesn't exist.
+func removeLine(path, want string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var kept []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == want {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return os.WriteFile(path, []byte(strings.Join(kept, "\n")), 0644)
+}
+
+// stripMarkerBlock removes the [start, end] marker-delimited block from
+// content, if present - used once to migrate configs edited by wizado
+// versions before the include-based approach.
+func stripMarkerBlock(content, startMarker, endMarker string) (string, bool) {
+	startIdx := strings.Index(content, startMarker)
+	endIdx := strings.Index(content, endMarker)
+	if startIdx == -1 || endIdx == -1 {
+		return content, false
+	}
+	return content[:startIdx] + content[endIdx+len(endMarker):], true
+}
+
+// ensureWaybarInclude adds includePath to the "include" array in the
+// waybar config at path, if it isn't already listed.
+func ensureWaybarInclude(path, includePath string) (changed bool, err error) {
+	out, err := exec.Command("jq", fmt.Sprintf(`(.include // []) | index(%q) != null`, includePath), path).Output()
+	if err == nil && strings.TrimSpace(string(out)) == "true" {
+		return false, nil
+	}
+
+	newData, err := exec.Command("jq", fmt.Sprintf(`.include = ((.include // []) + [%q] | unique)`, includePath), path).Output()
+	if err != nil {
+		return false, fmt.Errorf("jq: %w", err)
+	}
+	if err := os.WriteFile(path, newData, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeWaybarInclude removes includePath from the "include" array in the
+// waybar config at path, if present. It's a no-op if jq isn't available or
+// the config doesn't exist.
+func removeWaybarInclude(path, includePath string) {
+	if _, err := exec.LookPath("jq"); err != nil {
+		return
+	}
+	newData, err := exec.Command("jq", fmt.Sprintf(`.include = ((.include // []) - [%q])`, includePath), path).Output()
+	if err != nil {
+		return
+	}
+	os.WriteFile(path, newData, 0644)
+}
+
+// removeEmbeddedWaybarModule deletes a "custom/wizado" module embedded
+// directly in the waybar config at path, left over from wizado versions
+// that spliced the module in rather than using an include. It's a no-op if
+// there's nothing to remove.
+func removeEmbeddedWaybarModule(path string) {
+	out, err := exec.Command("jq", `has("custom/wizado")`, path).Output()
+	if err != nil || strings.TrimSpace(string(out)) != "true" {
+		return
+	}
+	newData, err := exec.Command("jq", `del(.["custom/wizado"])`, path).Output()
+	if err != nil {
+		return
+	}
+	os.WriteFile(path, newData, 0644)
+}
+
+// Reconcile brings the managed Hyprland and waybar fragments (and their
+// include lines) in line with the desired state, only touching files that
+// actually differ. configureKeybindings/configureWaybar already do this as
+// part of Run; Reconcile exposes the same idempotent step standalone, e.g.
+// for re-running setup after editing wizado.conf/wizado.jsonc by hand.
+func Reconcile(opts Options) error {
+	if err := configureKeybindings(opts); err != nil {
+		return err
+	}
+	return configureWaybar(opts)
+}
+
+// Uninstall removes the wizado-managed Hyprland/waybar fragments and their
+// include lines, reversing what configureKeybindings/configureWaybar (and
+// therefore Reconcile) set up.
+func Uninstall(opts Options) error {
+	if mainConfig, err := findHyprMainConfig(); err == nil {
+		if err := removeLine(mainConfig, hyprSourceLine); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(hyprManagedConfigPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(hyprPerfConfigPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(mangoHudConfigPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if mainConfig, err := findWaybarMainConfig(); err == nil {
+		removeWaybarInclude(mainConfig, waybarManagedConfigPath())
+	}
+	if err := os.Remove(waybarManagedConfigPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	fmt.Println("✓ Removed wizado-managed Hyprland/waybar config")
+	log.Info("Uninstalled managed Hyprland/waybar config")
+	return nil
+}
@@ -0,0 +1,138 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// secureBootState reflects whether the running system enforces signed
+// kernel modules - the condition under which an unsigned nvidia.ko silently
+// fails to load and Steam falls back to nouveau.
+type secureBootState struct {
+	Enabled bool
+}
+
+// detectSecureBoot reports whether Secure Boot is enabled, preferring
+// mokutil (the authoritative UEFI variable state) and falling back to the
+// kernel lockdown mode, which Secure Boot puts into effect on most distros
+// even when mokutil isn't installed.
+func detectSecureBoot() (secureBootState, error) {
+	if out, err := exec.Command("mokutil", "--sb-state").Output(); err == nil {
+		return secureBootState{Enabled: strings.Contains(string(out), "SecureBoot enabled")}, nil
+	}
+
+	data, err := os.ReadFile("/sys/kernel/security/lockdown")
+	if err != nil {
+		return secureBootState{}, fmt.Errorf("mokutil not found and lockdown state unavailable: %w", err)
+	}
+	// Format is e.g. "none [integrity] confidentiality" - the active mode is
+	// bracketed. Secure Boot puts the kernel in at least "integrity" lockdown.
+	enabled := strings.Contains(string(data), "[integrity]") || strings.Contains(string(data), "[confidentiality]")
+	return secureBootState{Enabled: enabled}, nil
+}
+
+// checkSecureBootForNVIDIA warns about, and if necessary refuses, installing
+// nvidia kernel modules under Secure Boot unless the caller passed
+// --sign-modules: an unsigned nvidia.ko fails to load under Secure Boot and
+// Steam silently falls back to nouveau, which is a confusing failure mode
+// to debug after the fact.
+func checkSecureBootForNVIDIA(opts Options) error {
+	state, err := detectSecureBoot()
+	if err != nil {
+		log.Warnf("Could not determine Secure Boot state: %v", err)
+		return nil
+	}
+	if !state.Enabled {
+		return nil
+	}
+
+	fmt.Println("⚠ Secure Boot is enabled - unsigned nvidia kernel modules will fail to load")
+	log.Warn("Secure Boot enabled; nvidia modules require signing")
+
+	if !opts.SignModules {
+		return fmt.Errorf("Secure Boot is enabled: enroll a key with sbctl (sbctl create-keys " +
+			"&& sbctl enroll-keys) or mokutil --import, then re-run with --sign-modules to sign " +
+			"the nvidia modules automatically")
+	}
+
+	fmt.Println("  Will sign nvidia kernel modules with sbctl after install (--sign-modules)")
+	return nil
+}
+
+// signNvidiaModules signs every nvidia kernel module under the running
+// kernel's video drivers directory with sbctl, so Secure Boot accepts them
+// without the user having to do it by hand after every driver update.
+func signNvidiaModules() error {
+	if _, err := exec.LookPath("sbctl"); err != nil {
+		return fmt.Errorf("sbctl not found - install it to sign nvidia modules")
+	}
+
+	out, err := exec.Command("uname", "-r").Output()
+	if err != nil {
+		return err
+	}
+	kernel := strings.TrimSpace(string(out))
+
+	dir := filepath.Join("/lib/modules", kernel, "kernel/drivers/video")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("no video drivers found under %s: %w", dir, err)
+	}
+
+	var signed int
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "nvidia") {
+			continue
+		}
+		if !strings.HasSuffix(name, ".ko") && !strings.HasSuffix(name, ".ko.zst") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		cmd := exec.Command("sudo", "sbctl", "sign", "-s", path)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to sign %s: %w", path, err)
+		}
+		signed++
+	}
+
+	if signed == 0 {
+		return fmt.Errorf("no nvidia kernel modules found under %s", dir)
+	}
+
+	fmt.Printf("✓ Signed %d nvidia kernel module(s) with sbctl\n", signed)
+	log.Infof("Signed %d nvidia kernel modules", signed)
+	return nil
+}
+
+// checkNVIDIAKernelMatch warns when nvidia-dkms hasn't yet built a module
+// for the currently running kernel - the common case right after a kernel
+// upgrade, where Steam falls back to nouveau until the user reboots into
+// the kernel dkms built for (or runs dkms autoinstall by hand).
+func checkNVIDIAKernelMatch(gpu GPUInfo) {
+	if !gpu.HasNVIDIA {
+		return
+	}
+
+	out, err := exec.Command("uname", "-r").Output()
+	if err != nil {
+		return
+	}
+	kernel := strings.TrimSpace(string(out))
+
+	status, err := exec.Command("dkms", "status", "nvidia").Output()
+	if err != nil {
+		return // dkms not in use, e.g. a precompiled nvidia-utils-only install
+	}
+	if !strings.Contains(string(status), kernel) {
+		fmt.Printf("⚠ nvidia-dkms has not built a module for the running kernel (%s) yet\n", kernel)
+		fmt.Println("  Reboot to let dkms finish, or run `sudo dkms autoinstall` before launching Steam")
+		log.Warnf("nvidia-dkms module not yet built for running kernel %s", kernel)
+	}
+}
@@ -3,7 +3,6 @@ package setup
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -22,12 +21,40 @@ type GPUInfo struct {
 	HasAMD     bool
 	HasIntel   bool
 	NVIDIAVkID string
+
+	// NVIDIADriverVersion is the installed nvidia driver version (e.g.
+	// "555.58.02"), empty if HasNVIDIA is false or it couldn't be read.
+	NVIDIADriverVersion string
 }
 
 // Options for setup
 type Options struct {
 	NonInteractive bool
 	DryRun         bool
+
+	// PackageManager installs/queries/removes packages and enables repos.
+	// Only consulted by ArchProvisioner; if nil, it auto-selects one via
+	// DetectPackageManager.
+	PackageManager PackageManager
+
+	// Provisioner performs the distro-specific steps of setup (installing
+	// dependencies, wiring up keybindings and Waybar). If nil, Run
+	// auto-selects one via DetectProvisioner.
+	Provisioner Provisioner
+
+	// SignModules, when Secure Boot is enabled, signs freshly-installed
+	// nvidia kernel modules with sbctl instead of refusing the nvidia
+	// install outright.
+	SignModules bool
+
+	// KeepPartial leaves changes in place if Run fails partway through,
+	// instead of automatically rolling back via the transaction snapshot.
+	KeepPartial bool
+
+	// tx records every mutation Run makes so a failed run can be rolled
+	// back. Set internally; nil when DryRun is true, since there's nothing
+	// to undo.
+	tx *Transaction
 }
 
 var log *logging.Logger
@@ -45,9 +72,22 @@ func Run(opts Options) error {
 	fmt.Println()
 	
 	log.Info("Starting wizado setup")
-	
+
+	if opts.Provisioner == nil {
+		opts.Provisioner = DetectProvisioner()
+	}
+	fmt.Printf("Using provisioner: %s\n", opts.Provisioner.Name())
+	log.Infof("Provisioner: %s", opts.Provisioner.Name())
+
+	var err error
+	opts, err = opts.Provisioner.Prepare(opts)
+	if err != nil {
+		log.Errorf("Provisioner setup failed: %v", err)
+		return err
+	}
+
 	// Validate environment first
-	if err := validateEnvironment(); err != nil {
+	if err := validateEnvironment(opts); err != nil {
 		log.Errorf("Environment validation failed: %v", err)
 		return err
 	}
@@ -79,10 +119,13 @@ func Run(opts Options) error {
 	
 	// Convert to our GPUInfo type
 	gpu := GPUInfo{
-		HasNVIDIA:  sysInfo.GPU.HasNVIDIA,
-		HasAMD:     sysInfo.GPU.HasAMD,
-		HasIntel:   sysInfo.GPU.HasIntel,
-		NVIDIAVkID: sysInfo.GPU.PrimaryID,
+		HasNVIDIA: sysInfo.GPU.HasNVIDIA(),
+		HasAMD:    sysInfo.GPU.HasAMD(),
+		HasIntel:  sysInfo.GPU.HasIntel(),
+	}
+	if nvidia := sysInfo.GPU.ByVendor("nvidia"); nvidia != nil {
+		gpu.NVIDIAVkID = nvidia.VendorID + ":" + nvidia.DeviceID
+		gpu.NVIDIADriverVersion = nvidia.DriverVersion
 	}
 	
 	// Confirm installation
@@ -91,53 +134,99 @@ func Run(opts Options) error {
 			return fmt.Errorf("installation cancelled")
 		}
 	}
-	
-	// Enable multilib
-	if err := ensureMultilib(opts); err != nil {
+
+	// Record a rollback snapshot of everything the steps below are about to
+	// touch, so a mid-run failure can be undone automatically.
+	if !opts.DryRun {
+		tx, err := newTransaction()
+		if err != nil {
+			log.Warnf("Could not start rollback snapshot: %v", err)
+		} else {
+			opts.tx = tx
+			fmt.Printf("Recording rollback snapshot: %s\n", tx.id)
+			log.Infof("Rollback snapshot: %s", tx.id)
+		}
+	}
+
+	if err := runMutations(opts, gpu); err != nil {
+		if opts.tx != nil {
+			if opts.KeepPartial {
+				fmt.Println("Leaving partial changes in place (--keep-partial).")
+				fmt.Printf("Roll them back later with: wizado setup --rollback=%s\n", opts.tx.id)
+			} else {
+				fmt.Println("Setup failed - rolling back changes made so far...")
+				if rbErr := opts.tx.Rollback(); rbErr != nil {
+					fmt.Printf("Warning: rollback incomplete: %v\n", rbErr)
+				} else {
+					fmt.Println("✓ Rolled back")
+				}
+			}
+		}
 		return err
 	}
-	
+
+	// Print success
+	printSuccess(gpu, sysInfo)
+
+	log.Info("Setup completed successfully")
+
+	return nil
+}
+
+// runMutations performs every step of Run that actually changes the
+// system, so Run can wrap the whole sequence in a single rollback-on-error
+// check rather than repeating it at each step.
+func runMutations(opts Options, gpu GPUInfo) error {
+	// Enable multilib (or its provisioner-specific equivalent)
+	if err := opts.Provisioner.EnsureRepos(opts); err != nil {
+		return err
+	}
+
 	// Install dependencies
-	if err := installDependencies(gpu, opts); err != nil {
+	if err := opts.Provisioner.InstallDependencies(gpu, opts); err != nil {
 		return err
 	}
-	
+
 	// Install optional packages
-	if err := installOptionalPackages(opts); err != nil {
+	if err := opts.Provisioner.InstallOptionalPackages(opts); err != nil {
 		// Non-fatal
 		fmt.Printf("Warning: some optional packages failed to install: %v\n", err)
 		log.Warnf("Optional packages failed: %v", err)
 	}
-	
+
 	// Check user groups
 	if err := checkUserGroups(opts); err != nil {
 		return err
 	}
-	
+
 	// Grant gamescope capabilities
 	if err := grantGamescopeCap(opts); err != nil {
 		// Non-fatal
 		fmt.Printf("Warning: could not grant gamescope cap_sys_nice: %v\n", err)
 		log.Warnf("Gamescope cap_sys_nice failed: %v", err)
 	}
-	
+
+	// Tune the compositor (VRR/HDR/explicit sync) for the detected monitor.
+	// Runs before ConfigureKeybindings so wizado.conf's source line already
+	// has a wizado-perf.conf to point at.
+	if err := opts.Provisioner.ConfigureCompositor(gpu, opts); err != nil {
+		// Non-fatal
+		fmt.Printf("Warning: could not tune compositor: %v\n", err)
+		log.Warnf("Compositor tuning failed: %v", err)
+	}
+
 	// Configure Hyprland keybindings
-	if err := configureKeybindings(opts); err != nil {
+	if err := opts.Provisioner.ConfigureKeybindings(opts); err != nil {
 		return err
 	}
-	
+
 	// Configure Waybar
-	if err := configureWaybar(opts); err != nil {
+	if err := opts.Provisioner.ConfigureWaybar(opts); err != nil {
 		// Non-fatal
 		fmt.Printf("Warning: could not configure waybar: %v\n", err)
 		log.Warnf("Waybar config failed: %v", err)
 	}
-	
-	// Print success
-	printSuccess(gpu, sysInfo)
-	
-	log.Info("Setup completed successfully")
-	
+
 	return nil
 }
 
@@ -171,9 +260,12 @@ func printSystemInfo(info *sysinfo.SystemInfo) {
 	// Hardware
 	fmt.Printf("│  CPU: %-53s │\n", truncate(info.CPU.Model, 53))
 	
-	gpuStr := info.GPU.Primary
-	if info.GPU.DriverVersion != "" {
-		gpuStr += " (v" + info.GPU.DriverVersion + ")"
+	gpuStr := "none detected"
+	if gpu := info.GPU.Primary(); gpu != nil {
+		gpuStr = gpu.Name
+		if gpu.DriverVersion != "" {
+			gpuStr += " (v" + gpu.DriverVersion + ")"
+		}
 	}
 	fmt.Printf("│  GPU: %-53s │\n", truncate(gpuStr, 53))
 	
@@ -233,16 +325,16 @@ func printSystemInfo(info *sysinfo.SystemInfo) {
 	fmt.Println("│  NETWORK                                                    │")
 	
 	if info.Network.HasInternet {
-		connType := info.Network.ConnectionType
-		if connType == "" {
-			connType = "connected"
-		}
-		if info.Network.SSID != "" {
-			connType = "WiFi: " + info.Network.SSID
+		connType := "connected"
+		if primary := info.Network.Primary(); primary != nil {
+			connType = primary.Type
+			if primary.SSID != "" {
+				connType = "WiFi: " + primary.SSID
+			}
 		}
 		fmt.Printf("│  ✓ Internet: %-46s │\n", connType)
 	} else {
-		fmt.Println("│  ✗ Internet: not connected                                  │")
+		fmt.Printf("│  ✗ Internet: %-46s │\n", info.Network.Status)
 	}
 	
 	fmt.Println("└─────────────────────────────────────────────────────────────┘")
@@ -268,12 +360,15 @@ func truncate(s string, max int) string {
 	return s[:max-3] + "..."
 }
 
-func validateEnvironment() error {
-	// Check for pacman
-	if _, err := exec.LookPath("pacman"); err != nil {
-		return fmt.Errorf("pacman not found - this tool is for Arch Linux")
+func validateEnvironment(opts Options) error {
+	// Check for pacman - only required on the Arch path; NixProvisioner
+	// doesn't shell out to a package manager at all.
+	if opts.Provisioner.Name() == "arch" {
+		if _, err := exec.LookPath("pacman"); err != nil {
+			return fmt.Errorf("pacman not found - this tool is for Arch Linux")
+		}
 	}
-	
+
 	// Check for hyprctl
 	if _, err := exec.LookPath("hyprctl"); err != nil {
 		return fmt.Errorf("hyprctl not found - is Hyprland installed?")
@@ -340,41 +435,27 @@ func ensureMultilib(opts Options) error {
 	if err != nil {
 		return err
 	}
-	
+
 	if strings.Contains(string(data), "[multilib]") && !strings.Contains(string(data), "#[multilib]") {
 		fmt.Println("✓ Multilib repository: enabled")
 		return nil
 	}
-	
+
 	fmt.Println("⚠ Multilib repository NOT enabled (required for Steam 32-bit libraries)")
 	log.Warn("Multilib repository not enabled")
-	
-	if opts.DryRun {
-		fmt.Println("[DRY RUN] Would enable multilib in /etc/pacman.conf")
-		return nil
-	}
-	
-	if !opts.NonInteractive {
+
+	if !opts.NonInteractive && !opts.DryRun {
 		if !confirm("Enable multilib in /etc/pacman.conf?") {
 			return fmt.Errorf("multilib required for Steam")
 		}
 	}
-	
-	// Enable multilib using sed
-	cmd := exec.Command("sudo", "sed", "-i", "/^#\\[multilib\\]/,/^#Include/ s/^#//", "/etc/pacman.conf")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to enable multilib: %v", err)
-	}
-	
-	// Refresh package database
-	fmt.Println("Refreshing package database...")
-	cmd = exec.Command("sudo", "pacman", "-Syy")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to refresh package database: %v", err)
+
+	opts.tx.BackupFile("/etc/pacman.conf")
+
+	if err := opts.PackageManager.AddRepo("multilib"); err != nil {
+		return err
 	}
-	
+
 	log.Info("Multilib repository enabled")
 	return nil
 }
@@ -396,48 +477,69 @@ func installDependencies(gpu GPUInfo, opts Options) error {
 	
 	// GPU-specific drivers
 	if gpu.HasNVIDIA {
-		deps = append(deps, "nvidia-utils", "lib32-nvidia-utils")
+		deps = append(deps, "nvidia-dkms", "nvidia-utils", "lib32-nvidia-utils")
 	}
 	if gpu.HasAMD {
 		deps = append(deps, "vulkan-radeon", "lib32-vulkan-radeon")
 	}
-	
+
 	// Check which are missing
 	var missing []string
 	for _, dep := range deps {
-		if !packageInstalled(dep) {
+		installed, _, _ := opts.PackageManager.Query(dep)
+		if !installed {
 			missing = append(missing, dep)
 		}
 	}
-	
+
 	if len(missing) == 0 {
 		fmt.Println("✓ All required dependencies installed")
+		checkNVIDIAKernelMatch(gpu)
 		return nil
 	}
-	
+
 	fmt.Printf("\nMissing required packages (%d):\n", len(missing))
 	for _, dep := range missing {
 		fmt.Printf("  • %s\n", dep)
 	}
-	
+
 	log.Infof("Missing %d required packages", len(missing))
-	
-	if opts.DryRun {
-		fmt.Println("[DRY RUN] Would install missing packages")
-		return nil
+
+	nvidiaModuleMissing := false
+	if gpu.HasNVIDIA {
+		for _, dep := range missing {
+			if dep == "nvidia-dkms" || dep == "nvidia-utils" {
+				nvidiaModuleMissing = true
+				break
+			}
+		}
 	}
-	
-	if !opts.NonInteractive {
+	if nvidiaModuleMissing {
+		if err := checkSecureBootForNVIDIA(opts); err != nil {
+			return err
+		}
+	}
+
+	if !opts.NonInteractive && !opts.DryRun {
 		if !confirm("Install missing packages?") {
 			return fmt.Errorf("dependencies required")
 		}
 	}
-	
-	args := append([]string{"pacman", "-S", "--needed", "--noconfirm"}, missing...)
-	cmd := exec.Command("sudo", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+
+	opts.tx.RecordPackagesInstalled(missing)
+	if err := opts.PackageManager.Install(missing); err != nil {
+		return err
+	}
+
+	if nvidiaModuleMissing && opts.SignModules {
+		if err := signNvidiaModules(); err != nil {
+			fmt.Printf("Warning: could not sign nvidia modules: %v\n", err)
+			log.Warnf("Signing nvidia modules failed: %v", err)
+		}
+	}
+
+	checkNVIDIAKernelMatch(gpu)
+	return nil
 }
 
 func installOptionalPackages(opts Options) error {
@@ -447,41 +549,40 @@ func installOptionalPackages(opts Options) error {
 		"mangohud",
 		"lib32-mangohud",
 	}
-	
+
+	// These aren't in the official repos, so they're only worth offering
+	// when an AUR helper is actually doing the installing.
+	if opts.PackageManager.Name() != "pacman" {
+		optional = append(optional, "gamescope-git", "mangohud-git", "proton-ge-custom-bin")
+	}
+
 	var missing []string
 	for _, pkg := range optional {
-		if !packageInstalled(pkg) {
+		installed, _, _ := opts.PackageManager.Query(pkg)
+		if !installed {
 			missing = append(missing, pkg)
 		}
 	}
-	
+
 	if len(missing) == 0 {
 		fmt.Println("✓ Optional packages already installed")
 		return nil
 	}
-	
+
 	fmt.Println("\nOptional packages (recommended for best performance):")
 	for _, pkg := range missing {
 		desc := getPackageDescription(pkg)
 		fmt.Printf("  • %s - %s\n", pkg, desc)
 	}
-	
-	if opts.DryRun {
-		fmt.Println("[DRY RUN] Would install optional packages")
-		return nil
-	}
-	
-	if !opts.NonInteractive {
+
+	if !opts.NonInteractive && !opts.DryRun {
 		if !confirm("Install optional packages?") {
 			return nil // Not an error to skip
 		}
 	}
-	
-	args := append([]string{"pacman", "-S", "--needed", "--noconfirm"}, missing...)
-	cmd := exec.Command("sudo", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+
+	opts.tx.RecordPackagesInstalled(missing)
+	return opts.PackageManager.Install(missing)
 }
 
 func getPackageDescription(pkg string) string {
@@ -494,6 +595,12 @@ func getPackageDescription(pkg string) string {
 		return "Performance overlay (FPS, temps)"
 	case "lib32-mangohud":
 		return "32-bit mangohud support"
+	case "gamescope-git":
+		return "Gaming compositor (HDR/frame-limiter patches, AUR)"
+	case "mangohud-git":
+		return "Performance overlay (latest, AUR)"
+	case "proton-ge-custom-bin":
+		return "Proton-GE compatibility layer (AUR)"
 	default:
 		return ""
 	}
@@ -537,7 +644,8 @@ func checkUserGroups(opts Options) error {
 	
 	user := os.Getenv("USER")
 	groupsCSV := strings.Join(missing, ",")
-	
+
+	opts.tx.RecordGroupsAdded(missing)
 	cmd := exec.Command("sudo", "usermod", "-aG", groupsCSV, user)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to add user to groups: %v", err)
@@ -575,188 +683,118 @@ func grantGamescopeCap(opts Options) error {
 		}
 	}
 	
+	opts.tx.RecordCapability(gamescopePath)
 	cmd := exec.Command("sudo", "setcap", "cap_sys_nice+ep", gamescopePath)
 	return cmd.Run()
 }
 
+// configureKeybindings ensures the self-contained, wizado-owned
+// hyprManagedConfigPath fragment exists and is sourced from the user's
+// Hyprland config. It never edits bindings in place: any bindings from the
+// old marker-delimited approach are stripped once, and everything else is
+// written through writeIfChanged/ensureSourceLine so re-running it is a
+// no-op once the managed state matches.
 func configureKeybindings(opts Options) error {
-	home, _ := os.UserHomeDir()
-	
-	// Find bindings config
-	bindingsPaths := []string{
-		filepath.Join(home, ".config", "hypr", "bindings.conf"),
-		filepath.Join(home, ".config", "hypr", "keybinds.conf"),
-		filepath.Join(home, ".config", "hypr", "hyprland.conf"),
+	mainConfig, err := findHyprMainConfig()
+	if err != nil {
+		return err
 	}
-	
-	var bindingsFile string
-	for _, path := range bindingsPaths {
-		if _, err := os.Stat(path); err == nil {
-			bindingsFile = path
-			break
+
+	fmt.Printf("Using Hyprland config: %s\n", mainConfig)
+
+	bindStyle := "bindd"
+	if data, err := os.ReadFile(mainConfig); err == nil {
+		content := string(data)
+		if stripped, changed := stripMarkerBlock(content, "# Wizado - added by wizado", "# End Wizado bindings"); changed {
+			os.WriteFile(mainConfig, []byte(stripped), 0644)
+		}
+		if !strings.Contains(content, "bindd") && strings.Contains(content, "bind =") {
+			bindStyle = "bind"
 		}
 	}
-	
-	if bindingsFile == "" {
-		return fmt.Errorf("could not find Hyprland bindings config")
-	}
-	
-	fmt.Printf("Using bindings config: %s\n", bindingsFile)
-	
+
+	managedPath := hyprManagedConfigPath()
+	desired := desiredHyprConfig(bindStyle)
+
 	if opts.DryRun {
-		fmt.Println("[DRY RUN] Would add keybindings to config")
+		fmt.Printf("[DRY RUN] Would write %s and source it from %s\n", managedPath, mainConfig)
 		return nil
 	}
-	
-	// Read current config
-	data, err := os.ReadFile(bindingsFile)
+
+	opts.tx.BackupFile(mainConfig)
+	opts.tx.BackupFile(managedPath)
+
+	wroteFragment, err := writeIfChanged(managedPath, desired)
 	if err != nil {
 		return err
 	}
-	
-	content := string(data)
-	
-	// Remove old wizado bindings
-	if strings.Contains(content, "# Wizado - added by wizado") {
-		// Find and remove the block
-		startMarker := "# Wizado - added by wizado"
-		endMarker := "# End Wizado bindings"
-		
-		startIdx := strings.Index(content, startMarker)
-		endIdx := strings.Index(content, endMarker)
-		
-		if startIdx != -1 && endIdx != -1 {
-			content = content[:startIdx] + content[endIdx+len(endMarker):]
-		}
-	}
-	
-	// Detect bind style (bind vs bindd)
-	bindStyle := "bindd"
-	if !strings.Contains(content, "bindd") && strings.Contains(content, "bind =") {
-		bindStyle = "bind"
-	}
-	
-	// Add new bindings
-	bindings := fmt.Sprintf(`
 
-# Wizado - added by wizado
-# Opens Wizado TUI menu on workspace 10
-`)
-	
-	if bindStyle == "bindd" {
-		bindings += `bindd = SUPER SHIFT, S, Wizado Menu, exec, wizado-menu-float
-bindd = SUPER SHIFT, Q, Kill Steam, exec, pkill -9 steam; pkill -9 gamescope
-`
-	} else {
-		bindings += `bind = SUPER SHIFT, S, exec, wizado-menu-float
-bind = SUPER SHIFT, Q, exec, pkill -9 steam; pkill -9 gamescope
-`
-	}
-	bindings += "# End Wizado bindings\n"
-	
-	content += bindings
-	
-	// Write back
-	if err := os.WriteFile(bindingsFile, []byte(content), 0644); err != nil {
+	sourcedLine, err := ensureSourceLine(mainConfig, hyprSourceLine)
+	if err != nil {
 		return err
 	}
-	
-	// Reload Hyprland
-	exec.Command("hyprctl", "reload").Run()
-	
-	fmt.Println("✓ Keybindings added: Super+Shift+S (menu), Super+Shift+Q (kill)")
+
+	if wroteFragment || sourcedLine {
+		exec.Command("hyprctl", "reload").Run()
+	}
+
+	fmt.Println("✓ Keybindings: Super+Shift+S (menu), Super+Shift+Q (kill)")
 	log.Info("Keybindings configured")
 	return nil
 }
 
+// configureWaybar ensures the self-contained, wizado-owned
+// waybarManagedConfigPath fragment exists and is included from the user's
+// waybar config via its "include" array, instead of splicing the module
+// definition directly into the main config. Any module embedded directly by
+// an older wizado version is removed once so the include becomes the only
+// source of truth.
 func configureWaybar(opts Options) error {
-	home, _ := os.UserHomeDir()
-	waybarDir := filepath.Join(home, ".config", "waybar")
-	
-	// Find waybar config
-	configPath := filepath.Join(waybarDir, "config.jsonc")
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		configPath = filepath.Join(waybarDir, "config")
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			return fmt.Errorf("waybar config not found")
-		}
+	mainConfig, err := findWaybarMainConfig()
+	if err != nil {
+		return err
 	}
-	
-	fmt.Printf("Using waybar config: %s\n", configPath)
-	
+
+	fmt.Printf("Using waybar config: %s\n", mainConfig)
+
+	managedPath := waybarManagedConfigPath()
+	desired := desiredWaybarModule()
+
 	if opts.DryRun {
-		fmt.Println("[DRY RUN] Would add wizado module to waybar")
+		fmt.Printf("[DRY RUN] Would write %s and include it from %s\n", managedPath, mainConfig)
 		return nil
 	}
-	
-	// Read config
-	data, err := os.ReadFile(configPath)
+
+	if _, err := exec.LookPath("jq"); err != nil {
+		fmt.Println("Could not automatically manage waybar include (jq not found).")
+		fmt.Printf("Add %q to the \"include\" array in %s, pointing at:\n", managedPath, mainConfig)
+		fmt.Println(desired)
+		return nil
+	}
+
+	opts.tx.BackupFile(mainConfig)
+	opts.tx.BackupFile(managedPath)
+	removeEmbeddedWaybarModule(mainConfig)
+
+	wroteFragment, err := writeIfChanged(managedPath, desired)
 	if err != nil {
 		return err
 	}
-	
-	content := string(data)
-	
-	// Check if module already exists
-	if strings.Contains(content, `"custom/wizado"`) {
-		fmt.Println("✓ Wizado module already exists in waybar config")
+
+	included, err := ensureWaybarInclude(mainConfig, managedPath)
+	if err != nil {
+		fmt.Printf("Could not automatically include the wizado waybar module: %v\n", err)
+		fmt.Printf("Add %q to the \"include\" array in %s\n", managedPath, mainConfig)
 		return nil
 	}
-	
-	// Try to add module using jq
-	// Note: on-click uses wizado-menu-float to spawn a terminal for the TUI
-	moduleJSON := `{
-    "custom/wizado": {
-        "format": "{}",
-        "return-type": "json",
-        "exec": "wizado status",
-        "on-click": "wizado-menu-float",
-        "on-click-right": "wizado-menu-float",
-        "interval": 60,
-        "tooltip": true
-    }
-}`
-	
-	// Try jq approach
-	if _, err := exec.LookPath("jq"); err == nil {
-		// First add to modules-right
-		cmd := exec.Command("jq", `if .["modules-right"] then .["modules-right"] = ["custom/wizado"] + .["modules-right"] else . end`, configPath)
-		out, err := cmd.Output()
-		if err == nil {
-			// Then add the module definition
-			var config map[string]interface{}
-			if err := json.Unmarshal(out, &config); err == nil {
-				config["custom/wizado"] = map[string]interface{}{
-					"format":         "{}",
-					"return-type":    "json",
-					"exec":           "wizado status",
-					"on-click":       "wizado-menu-float",
-					"on-click-right": "wizado-menu-float",
-					"interval":       60,
-					"tooltip":        true,
-				}
-				
-				newData, err := json.MarshalIndent(config, "", "  ")
-				if err == nil {
-					os.WriteFile(configPath, newData, 0644)
-					fmt.Println("✓ Added wizado module to waybar config")
-					
-					// Restart waybar
-					exec.Command("pkill", "waybar").Run()
-					go exec.Command("waybar").Start()
-					
-					log.Info("Waybar module configured")
-					return nil
-				}
-			}
-		}
+
+	if wroteFragment || included {
+		exec.Command("pkill", "waybar").Run()
+		go exec.Command("waybar").Start()
 	}
-	
-	// Fallback: print instructions
-	fmt.Println("Could not automatically add waybar module.")
-	fmt.Println("Add the following to your waybar config:")
-	fmt.Println(moduleJSON)
-	
+
+	fmt.Println("✓ Waybar module configured")
+	log.Info("Waybar module configured")
 	return nil
 }
 
@@ -818,11 +856,6 @@ func printSuccess(gpu GPUInfo, sysInfo *sysinfo.SystemInfo) {
 	fmt.Println()
 }
 
-func packageInstalled(name string) bool {
-	cmd := exec.Command("pacman", "-Qi", name)
-	return cmd.Run() == nil
-}
-
 func confirm(prompt string) bool {
 	fmt.Printf("%s [y/N]: ", prompt)
 	reader := bufio.NewReader(os.Stdin)
@@ -0,0 +1,261 @@
+package setup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotRoot is where Transaction stores its per-run backup directories,
+// so a failed or exploratory setup run can always be undone even after the
+// process that made it has exited.
+func snapshotRoot() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "state", "wizado", "snapshots")
+}
+
+// fileBackup records what a mutating step found at path before it wrote to
+// it, so Rollback can put it back exactly.
+type fileBackup struct {
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+	Backup  string `json:"backup,omitempty"` // snapshot copy, set only if Existed
+}
+
+// capBackup records a file's capabilities (getcap output) before
+// grantGamescopeCap changed them.
+type capBackup struct {
+	Path string `json:"path"`
+	Caps string `json:"caps"` // empty means no capabilities were set
+}
+
+// transactionManifest is the on-disk record of everything a Run mutated,
+// serialized to manifest.json inside the snapshot directory.
+type transactionManifest struct {
+	ID                string       `json:"id"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	BackedUpFiles     []fileBackup `json:"backedUpFiles"`
+	Capabilities      []capBackup  `json:"capabilities"`
+	GroupsAdded       []string     `json:"groupsAdded"`
+	PackagesInstalled []string     `json:"packagesInstalled"`
+}
+
+// Transaction records every mutation a Run makes - file edits, capability
+// grants, group membership, and package installs - into a timestamped
+// snapshot directory, so a failed or unwanted run can be rolled back.
+type Transaction struct {
+	id       string
+	dir      string
+	backedUp map[string]bool
+	manifest transactionManifest
+}
+
+// newTransaction starts a new snapshot directory under snapshotRoot, named
+// after the current time so RollbackSnapshot can find it (or the most
+// recent one) later.
+func newTransaction() (*Transaction, error) {
+	id := time.Now().UTC().Format("20060102-150405")
+	dir := filepath.Join(snapshotRoot(), id)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	t := &Transaction{
+		id:       id,
+		dir:      dir,
+		backedUp: make(map[string]bool),
+		manifest: transactionManifest{ID: id, CreatedAt: time.Now().UTC()},
+	}
+	return t, t.save()
+}
+
+func (t *Transaction) save() error {
+	data, err := json.MarshalIndent(t.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(t.dir, "manifest.json"), data, 0600)
+}
+
+// BackupFile snapshots path's current contents (or records that it didn't
+// exist yet) before a mutating step writes to it. Safe to call on a nil
+// Transaction (a no-op, e.g. during a dry run) and more than once for the
+// same path within one transaction - only the first call is recorded.
+func (t *Transaction) BackupFile(path string) error {
+	if t == nil || t.backedUp[path] {
+		return nil
+	}
+	t.backedUp[path] = true
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.manifest.BackedUpFiles = append(t.manifest.BackedUpFiles, fileBackup{Path: path, Existed: false})
+		return t.save()
+	}
+	if err != nil {
+		return err
+	}
+
+	backupPath := filepath.Join(t.dir, fmt.Sprintf("file-%d.bak", len(t.manifest.BackedUpFiles)))
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return err
+	}
+
+	t.manifest.BackedUpFiles = append(t.manifest.BackedUpFiles, fileBackup{Path: path, Existed: true, Backup: backupPath})
+	return t.save()
+}
+
+// RecordCapability snapshots path's current getcap output before
+// grantGamescopeCap changes it, so Rollback can strip what it granted.
+func (t *Transaction) RecordCapability(path string) error {
+	if t == nil {
+		return nil
+	}
+	out, _ := exec.Command("getcap", path).Output()
+	t.manifest.Capabilities = append(t.manifest.Capabilities, capBackup{Path: path, Caps: strings.TrimSpace(string(out))})
+	return t.save()
+}
+
+// RecordGroupsAdded records which groups checkUserGroups is about to add
+// the user to, so Rollback can remove them again.
+func (t *Transaction) RecordGroupsAdded(groups []string) error {
+	if t == nil || len(groups) == 0 {
+		return nil
+	}
+	t.manifest.GroupsAdded = append(t.manifest.GroupsAdded, groups...)
+	return t.save()
+}
+
+// RecordPackagesInstalled records packages a PackageManager.Install call is
+// about to install, so Rollback can offer to remove them again.
+func (t *Transaction) RecordPackagesInstalled(pkgs []string) error {
+	if t == nil || len(pkgs) == 0 {
+		return nil
+	}
+	t.manifest.PackagesInstalled = append(t.manifest.PackagesInstalled, pkgs...)
+	return t.save()
+}
+
+// Rollback undoes every mutation recorded in this transaction.
+func (t *Transaction) Rollback() error {
+	if t == nil {
+		return nil
+	}
+	return rollbackManifest(t.manifest)
+}
+
+// rollbackManifest restores files, capabilities, and group membership
+// recorded in m, and prints the pacman command to remove the packages it
+// attributes to that run - actually removing packages isn't done
+// automatically, since an installed package may have been depended on by
+// something else in the meantime.
+func rollbackManifest(m transactionManifest) error {
+	var errs []string
+
+	for i := len(m.BackedUpFiles) - 1; i >= 0; i-- {
+		fb := m.BackedUpFiles[i]
+		if fb.Existed {
+			data, err := os.ReadFile(fb.Backup)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("restore %s: %v", fb.Path, err))
+				continue
+			}
+			if err := os.WriteFile(fb.Path, data, 0644); err != nil {
+				errs = append(errs, fmt.Sprintf("restore %s: %v", fb.Path, err))
+			}
+		} else if err := os.Remove(fb.Path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("remove %s: %v", fb.Path, err))
+		}
+	}
+
+	for _, cb := range m.Capabilities {
+		if cb.Caps == "" {
+			exec.Command("sudo", "setcap", "-r", cb.Path).Run()
+			continue
+		}
+		// getcap prints "path = caps"; setcap wants "caps path".
+		if idx := strings.Index(cb.Caps, "="); idx != -1 {
+			caps := strings.TrimSpace(cb.Caps[idx+1:])
+			exec.Command("sudo", "setcap", caps, cb.Path).Run()
+		}
+	}
+
+	if len(m.GroupsAdded) > 0 {
+		user := os.Getenv("USER")
+		for _, group := range m.GroupsAdded {
+			exec.Command("sudo", "gpasswd", "-d", user, group).Run()
+		}
+	}
+
+	if len(m.PackagesInstalled) > 0 {
+		fmt.Println("Packages installed by this run:")
+		for _, pkg := range m.PackagesInstalled {
+			fmt.Printf("  • %s\n", pkg)
+		}
+		fmt.Printf("Remove them with: sudo pacman -Rns %s\n", strings.Join(m.PackagesInstalled, " "))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("rollback had %d error(s): %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// RollbackSnapshot restores the files, capabilities, and group membership
+// recorded in the snapshot named id, or the most recent snapshot if id is
+// empty.
+func RollbackSnapshot(id string) error {
+	if id == "" {
+		latest, err := latestSnapshotID()
+		if err != nil {
+			return err
+		}
+		id = latest
+	}
+
+	data, err := os.ReadFile(filepath.Join(snapshotRoot(), id, "manifest.json"))
+	if err != nil {
+		return fmt.Errorf("no snapshot %q: %w", id, err)
+	}
+
+	var m transactionManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	if err := rollbackManifest(m); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Rolled back snapshot %s\n", id)
+	log.Infof("Rolled back snapshot %s", id)
+	return nil
+}
+
+// latestSnapshotID returns the most recently created snapshot directory
+// name under snapshotRoot - the IDs are timestamps, so lexical order is
+// chronological order.
+func latestSnapshotID() (string, error) {
+	entries, err := os.ReadDir(snapshotRoot())
+	if err != nil {
+		return "", fmt.Errorf("no snapshots found: %w", err)
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() {
+			ids = append(ids, e.Name())
+		}
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no snapshots found")
+	}
+
+	sort.Strings(ids)
+	return ids[len(ids)-1], nil
+}
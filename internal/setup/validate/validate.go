@@ -0,0 +1,143 @@
+// Package validate runs post-install conformance probes against a wizado
+// install: small, independent checks in the spirit of igt-gpu-tools -
+// always-runnable, narrowly scoped, and safe to run repeatedly - rather
+// than a full test suite. `wizado doctor` is what drives this package.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Result is the outcome of a single probe.
+type Result struct {
+	Name   string `json:"name"`
+	Pass   bool   `json:"pass"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Probe is a single, independent conformance check.
+type Probe struct {
+	Name string
+	Run  func() Result
+}
+
+// Probes is every probe RunAll runs, in the order results are reported.
+var Probes = []Probe{
+	{Name: "vulkan", Run: probeVulkan},
+	{Name: "vkcube", Run: probeVkcube},
+	{Name: "glx", Run: probeGLX},
+	{Name: "gamescope", Run: probeGamescope},
+	{Name: "pipewire", Run: probePipeWire},
+	{Name: "input", Run: probeInput},
+	{Name: "steam", Run: probeSteam},
+}
+
+// RunAll runs every probe in order and returns their results.
+func RunAll() []Result {
+	results := make([]Result, 0, len(Probes))
+	for _, p := range Probes {
+		results = append(results, p.Run())
+	}
+	return results
+}
+
+// runWithTimeout runs name with args and returns its combined output,
+// killing it if it hasn't finished within timeout - several of these
+// probes (vkcube, gamescope) would otherwise hang waiting on a surface
+// that never presents.
+func runWithTimeout(timeout time.Duration, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	return string(out), err
+}
+
+func probeVulkan() Result {
+	out, err := runWithTimeout(10*time.Second, "vulkaninfo", "--summary")
+	if err != nil {
+		return Result{Name: "vulkan", Detail: fmt.Sprintf("vulkaninfo failed: %v", err),
+			Fix: "install vulkan-icd-loader and your GPU's vulkan driver"}
+	}
+
+	lower := strings.ToLower(out)
+	var driver string
+	switch {
+	case strings.Contains(lower, "radv"):
+		driver = "radv"
+	case strings.Contains(lower, "nvidia"):
+		driver = "nvidia"
+	case strings.Contains(lower, "anv") || strings.Contains(lower, "intel"):
+		driver = "anv"
+	}
+	if driver == "" {
+		return Result{Name: "vulkan", Detail: "no known Vulkan ICD (radv/nvidia/anv) found in vulkaninfo output",
+			Fix: "check GPU drivers are installed and the vendor ICD is selected"}
+	}
+	return Result{Name: "vulkan", Pass: true, Detail: fmt.Sprintf("Vulkan ICD: %s", driver)}
+}
+
+func probeVkcube() Result {
+	if _, err := runWithTimeout(15*time.Second, "vkcube", "--c", "30"); err != nil {
+		return Result{Name: "vkcube", Detail: fmt.Sprintf("vkcube failed: %v", err),
+			Fix: "check the Vulkan driver install and that WAYLAND_DISPLAY/DISPLAY is set"}
+	}
+	return Result{Name: "vkcube", Pass: true, Detail: "rendered 30 frames"}
+}
+
+func probeGLX() Result {
+	out, err := runWithTimeout(10*time.Second, "glxinfo", "-B")
+	if err != nil {
+		return Result{Name: "glx", Detail: fmt.Sprintf("glxinfo failed: %v", err),
+			Fix: "install mesa-utils (glxinfo)"}
+	}
+	if !strings.Contains(out, "direct rendering: Yes") {
+		return Result{Name: "glx", Detail: "GLX reports indirect rendering",
+			Fix: "check GPU drivers and DRI device permissions"}
+	}
+	return Result{Name: "glx", Pass: true, Detail: "direct rendering: Yes"}
+}
+
+func probeGamescope() Result {
+	if _, err := runWithTimeout(20*time.Second, "gamescope", "-e", "--", "vkcube", "--c", "30"); err != nil {
+		return Result{Name: "gamescope", Detail: fmt.Sprintf("gamescope failed to start: %v", err),
+			Fix: "install gamescope and grant cap_sys_nice (wizado setup)"}
+	}
+	return Result{Name: "gamescope", Pass: true, Detail: "compositor started and rendered"}
+}
+
+func probePipeWire() Result {
+	out, err := runWithTimeout(5*time.Second, "pw-cli", "info", "0")
+	if err != nil {
+		return Result{Name: "pipewire", Detail: fmt.Sprintf("pw-cli failed: %v", err),
+			Fix: "install pipewire and check it's running (systemctl --user status pipewire)"}
+	}
+	summary := strings.SplitN(strings.TrimSpace(out), "\n", 2)[0]
+	return Result{Name: "pipewire", Pass: true, Detail: summary}
+}
+
+func probeInput() Result {
+	out, err := runWithTimeout(5*time.Second, "libinput", "list-devices")
+	if err != nil {
+		return Result{Name: "input", Detail: fmt.Sprintf("libinput failed: %v", err),
+			Fix: "install libinput and add the user to the input group (wizado setup)"}
+	}
+	devices := strings.Count(out, "Device:")
+	if devices == 0 {
+		return Result{Name: "input", Detail: "no input devices detected",
+			Fix: "check the user is in the input group and devices are plugged in"}
+	}
+	return Result{Name: "input", Pass: true, Detail: fmt.Sprintf("%d input device(s) detected", devices)}
+}
+
+func probeSteam() Result {
+	if _, err := runWithTimeout(10*time.Second, "steam", "-shutdown"); err != nil {
+		return Result{Name: "steam", Detail: fmt.Sprintf("steam -shutdown failed: %v", err),
+			Fix: "check steam is installed (wizado setup)"}
+	}
+	return Result{Name: "steam", Pass: true, Detail: "steam responded to -shutdown"}
+}
@@ -0,0 +1,181 @@
+// Package state persists a record of every live wizado session so a crash
+// in the launching process doesn't leave sandbox ACLs or D-Bus proxies
+// orphaned - `wizado ps` reads this registry and
+// `wizado reap` uses it to finish the cleanup a dead session never got to.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/wattfource/wizado/internal/hooks"
+	"github.com/wattfource/wizado/internal/logging"
+)
+
+var log *logging.Logger
+
+func init() {
+	log = logging.WithComponent("state")
+}
+
+// Session is the on-disk record for one live gaming session.
+type Session struct {
+	PID          int       `json:"pid"`
+	SessionID    string    `json:"session_id"`
+	StartTime    time.Time `json:"start_time"`
+	SandboxUser  string    `json:"sandbox_user,omitempty"`
+	Enablements  string    `json:"enablements"`
+	ACLPaths     []string  `json:"acl_paths,omitempty"`
+	DBusProxyPID int       `json:"dbus_proxy_pid,omitempty"`
+	LogPath      string    `json:"log_path,omitempty"`
+	// PostLaunchHooks are run by Reap if the session dies before running them itself
+	PostLaunchHooks []string `json:"post_launch_hooks,omitempty"`
+}
+
+func dir() (string, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = os.TempDir()
+	}
+	d := filepath.Join(runtimeDir, "wizado", "state")
+	if err := os.MkdirAll(d, 0700); err != nil {
+		return "", err
+	}
+	return d, nil
+}
+
+func path(pid int) (string, error) {
+	d, err := dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, fmt.Sprintf("%d.json", pid)), nil
+}
+
+// Register persists s under its PID, atomically so `wizado ps`/`wizado reap`
+// never observe a partially-written record.
+func Register(s *Session) error {
+	p, err := path(s.PID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// Remove deletes the registry entry for pid, called once its session's
+// cleanup has completed normally.
+func Remove(pid int) error {
+	p, err := path(pid)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(p)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// List returns every registered session, oldest first.
+func List() ([]*Session, error) {
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*Session
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(d, e.Name()))
+		if err != nil {
+			continue
+		}
+		var s Session
+		if err := json.Unmarshal(data, &s); err != nil {
+			log.Warnf("Skipping malformed session record %s: %v", e.Name(), err)
+			continue
+		}
+		sessions = append(sessions, &s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+	return sessions, nil
+}
+
+// IsAlive reports whether pid still refers to a running process.
+func IsAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// Reap walks the registry for sessions whose process has died without
+// cleaning up after itself, finishes that cleanup (revoking ACLs, killing
+// the D-Bus proxy), removes the stale entry, and returns the sessions it
+// reaped.
+func Reap() ([]*Session, error) {
+	sessions, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []*Session
+	for _, s := range sessions {
+		if IsAlive(s.PID) {
+			continue
+		}
+
+		if s.DBusProxyPID > 0 {
+			if proc, err := os.FindProcess(s.DBusProxyPID); err == nil {
+				proc.Kill()
+			}
+		}
+
+		if s.SandboxUser != "" {
+			for _, p := range s.ACLPaths {
+				if err := exec.Command("setfacl", "-x", "u:"+s.SandboxUser, p).Run(); err != nil {
+					log.Debugf("Could not revoke %s access to %s: %v", s.SandboxUser, p, err)
+				}
+			}
+			exec.Command("pkill", "-9", "-u", s.SandboxUser).Run()
+		}
+
+		if len(s.PostLaunchHooks) > 0 {
+			hooks.Run(s.PostLaunchHooks, "post_launch (reaped)")
+		}
+
+		if err := Remove(s.PID); err != nil {
+			log.Warnf("Could not remove stale session record for pid %d: %v", s.PID, err)
+		}
+		stale = append(stale, s)
+	}
+
+	return stale, nil
+}
@@ -0,0 +1,119 @@
+package sysinfo
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// BoardInfo is the DMI/SMBIOS fingerprint of the motherboard/chassis, read
+// from /sys/class/dmi/id. It's stable across reboots and reinstalls, so it's
+// the natural key for per-model quirk profiles (fan curves, power profiles,
+// screen scale, controller wiring) without asking the user what they have.
+type BoardInfo struct {
+	Vendor      string `json:"vendor"`
+	Product     string `json:"product"`
+	Version     string `json:"version"`
+	Serial      string `json:"serial,omitempty"`
+	ChassisType string `json:"chassis_type,omitempty"`
+	IsLaptop    bool   `json:"is_laptop"`
+	BIOSVendor  string `json:"bios_vendor,omitempty"`
+	BIOSVersion string `json:"bios_version,omitempty"`
+	BIOSDate    string `json:"bios_date,omitempty"`
+}
+
+// BoardID returns a stable identifier for this board, "vendor/product/version",
+// suitable for keying per-model quirk profiles (Framework 13/16, Legion Go,
+// ROG Ally, Steam Deck OLED, ThinkPad Z13, ...).
+func (b BoardInfo) BoardID() string {
+	return b.Vendor + "/" + b.Product + "/" + b.Version
+}
+
+// chassisTypeNames maps the SMBIOS chassis type codes we care about to a
+// human-readable name. Codes not listed here are reported as their raw number.
+var chassisTypeNames = map[int]string{
+	3:  "Desktop",
+	4:  "Low Profile Desktop",
+	6:  "Mini Tower",
+	7:  "Tower",
+	8:  "Portable",
+	9:  "Laptop",
+	10: "Notebook",
+	13: "All in One",
+	14: "Sub Notebook",
+	30: "Tablet",
+	31: "Convertible",
+	32: "Detachable",
+}
+
+// laptopChassisTypes are the SMBIOS chassis codes considered a laptop/handheld
+// form factor for IsLaptop.
+var laptopChassisTypes = map[int]bool{
+	8:  true,
+	9:  true,
+	10: true,
+	14: true,
+	30: true,
+	31: true,
+	32: true,
+}
+
+// collectBoard reads the DMI/SMBIOS board fingerprint exposed by the kernel
+// under /sys/class/dmi/id. Most of these files require root to read on some
+// distros; a permission error just leaves the field empty.
+func collectBoard() BoardInfo {
+	info := BoardInfo{
+		Vendor:      strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/board_vendor")),
+		Product:     strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/board_name")),
+		Version:     strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/board_version")),
+		Serial:      strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/product_serial")),
+		BIOSVendor:  strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/bios_vendor")),
+		BIOSVersion: strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/bios_version")),
+		BIOSDate:    strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/bios_date")),
+	}
+
+	if raw := strings.TrimSpace(readFileOrEmpty("/sys/class/dmi/id/chassis_type")); raw != "" {
+		if code, err := strconv.Atoi(raw); err == nil {
+			if name, ok := chassisTypeNames[code]; ok {
+				info.ChassisType = name
+			} else {
+				info.ChassisType = raw
+			}
+			info.IsLaptop = laptopChassisTypes[code]
+		}
+	}
+
+	return info
+}
+
+// ECInfo describes the embedded controller firmware, where the platform
+// exposes one - used alongside BoardInfo to distinguish firmware revisions
+// of the same board (e.g. Steam Deck EC updates changing fan behavior).
+type ECInfo struct {
+	FirmwareRelease string `json:"firmware_release,omitempty"`
+	Available       bool   `json:"available"`
+}
+
+// collectEC reads the embedded controller firmware release from sysfs where
+// the kernel exposes it, falling back to dmidecode (which needs root) for
+// boards that don't.
+func collectEC() ECInfo {
+	info := ECInfo{}
+
+	if data, err := os.ReadFile("/sys/class/dmi/id/ec_firmware_release"); err == nil {
+		info.FirmwareRelease = strings.TrimSpace(string(data))
+		info.Available = info.FirmwareRelease != ""
+	}
+
+	if !info.Available {
+		if out, err := exec.Command("dmidecode", "-s", "bios-embedded-controller-firmware-release").Output(); err == nil {
+			if version := strings.TrimSpace(string(out)); version != "" {
+				info.FirmwareRelease = version
+				info.Available = true
+			}
+		}
+	}
+
+	return info
+}
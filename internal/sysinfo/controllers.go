@@ -0,0 +1,120 @@
+package sysinfo
+
+import "strings"
+
+// controllerProfile describes what we know about a specific (vendor, product)
+// USB/Bluetooth ID pair once it's been canonicalized.
+type controllerProfile struct {
+	Canonical   string
+	Layout      string // "xinput", "dinput", "hid"
+	HasGyro     bool
+	HasRumble   bool
+	HasTouchpad bool
+}
+
+// usbVendors maps a handful of USB vendor IDs (lowercase hex, no "0x") to
+// their display name, used to populate InputDevice.Vendor when sysfs doesn't
+// already give us one.
+var usbVendors = map[string]string{
+	"045e": "Microsoft",
+	"054c": "Sony",
+	"057e": "Nintendo",
+	"28de": "Valve",
+	"2dc8": "8BitDo",
+	"18d1": "Google",
+	"0079": "DragonRise",
+	"046d": "Logitech",
+}
+
+// controllerProfiles maps "vendor:product" (lowercase hex) to a canonical
+// controller profile for the pads we can positively identify. Pairs that
+// aren't here get no profile and keep an empty Canonical, except 8BitDo
+// pads (vendor 2dc8), which canonicalizeController matches by vendor alone.
+var controllerProfiles = map[string]controllerProfile{
+	// Xbox 360
+	"045e:028e": {Canonical: "xbox360", Layout: "xinput", HasRumble: true},
+	"045e:028f": {Canonical: "xbox360", Layout: "xinput", HasRumble: true},
+	// Xbox One / Series
+	"045e:02d1": {Canonical: "xbone", Layout: "xinput", HasRumble: true},
+	"045e:02dd": {Canonical: "xbone", Layout: "xinput", HasRumble: true},
+	"045e:02ea": {Canonical: "xbone", Layout: "xinput", HasRumble: true},
+	"045e:0b12": {Canonical: "xbone", Layout: "xinput", HasRumble: true},
+	"045e:0b13": {Canonical: "xbone", Layout: "xinput", HasRumble: true},
+	// DualShock 3
+	"054c:0268": {Canonical: "dualshock3", Layout: "hid", HasRumble: true},
+	// DualShock 4
+	"054c:05c4": {Canonical: "dualshock4", Layout: "hid", HasRumble: true, HasTouchpad: true},
+	"054c:09cc": {Canonical: "dualshock4", Layout: "hid", HasRumble: true, HasTouchpad: true},
+	// DualSense
+	"054c:0ce6": {Canonical: "dualsense", Layout: "hid", HasGyro: true, HasRumble: true, HasTouchpad: true},
+	"054c:0df2": {Canonical: "dualsense", Layout: "hid", HasGyro: true, HasRumble: true, HasTouchpad: true},
+	// Switch Pro Controller
+	"057e:2009": {Canonical: "switch-pro", Layout: "hid", HasGyro: true, HasRumble: true},
+	// Steam Controller
+	"28de:1102": {Canonical: "steam-controller", Layout: "hid", HasTouchpad: true, HasRumble: true},
+	"28de:1142": {Canonical: "steam-controller", Layout: "hid", HasTouchpad: true, HasRumble: true},
+	// Steam Deck built-in controls
+	"28de:1205": {Canonical: "steam-deck", Layout: "hid", HasGyro: true, HasRumble: true, HasTouchpad: true},
+	// Stadia Controller
+	"18d1:9400": {Canonical: "stadia", Layout: "hid"},
+}
+
+// canonicalizeController looks up the canonical controller type for a
+// (vendor, product) ID pair, falling back to 8BitDo's "one vendor, many
+// clones" scheme since its product IDs vary by firmware/mode.
+func canonicalizeController(vendorID, productID string) (controllerProfile, bool) {
+	vendorID = strings.ToLower(vendorID)
+	productID = strings.ToLower(productID)
+
+	if p, ok := controllerProfiles[vendorID+":"+productID]; ok {
+		return p, true
+	}
+	if vendorID == "2dc8" {
+		return controllerProfile{Canonical: "8bitdo-" + productID, Layout: "xinput", HasRumble: true}, true
+	}
+	return controllerProfile{}, false
+}
+
+// parseInputIDLine parses a "/proc/bus/input/devices" I: line, e.g.
+// "I: Bus=0003 Vendor=045e Product=028e Version=0110", returning the
+// lowercase hex vendor and product IDs.
+func parseInputIDLine(line string) (vendorID, productID string) {
+	for _, field := range strings.Fields(strings.TrimPrefix(line, "I:")) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "Vendor":
+			vendorID = strings.ToLower(kv[1])
+		case "Product":
+			productID = strings.ToLower(kv[1])
+		}
+	}
+	return vendorID, productID
+}
+
+// fingerprintController fills in the vendor/product/canonical/layout/
+// capability fields on device from a "/proc/bus/input/devices" block.
+func fingerprintController(device *InputDevice, block string) {
+	for _, line := range strings.Split(block, "\n") {
+		if strings.HasPrefix(line, "I:") {
+			vendorID, productID := parseInputIDLine(line)
+			if vendorID == "" || productID == "" {
+				continue
+			}
+			device.VendorID = vendorID
+			device.ProductID = productID
+			if device.Vendor == "" {
+				device.Vendor = usbVendors[vendorID]
+			}
+			if profile, ok := canonicalizeController(vendorID, productID); ok {
+				device.Canonical = profile.Canonical
+				device.Layout = profile.Layout
+				device.HasGyro = profile.HasGyro
+				device.HasRumble = profile.HasRumble
+				device.HasTouchpad = profile.HasTouchpad
+			}
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// FeatureName identifies one entry in the hardware capability matrix.
+type FeatureName string
+
+const (
+	FeatureVulkan            FeatureName = "vulkan"
+	FeatureVKD3D             FeatureName = "vkd3d"
+	FeatureRayTracing        FeatureName = "ray_tracing"
+	FeatureHDR               FeatureName = "hdr"
+	FeatureVRR               FeatureName = "vrr"
+	FeatureHiDPI             FeatureName = "hidpi"
+	FeatureGamescopeMangoapp FeatureName = "gamescope_mangoapp"
+	FeatureHDRMetadata       FeatureName = "hdr_metadata"
+	FeatureWayland           FeatureName = "wayland"
+	FeaturePipeWire          FeatureName = "pipewire"
+	FeatureSteamMinSpec      FeatureName = "steam_min_spec"
+	FeatureSteamRecommended  FeatureName = "steam_recommended_spec"
+)
+
+// Features is a boolean/enum capability matrix derived from a SystemInfo
+// snapshot, so callers can ask "can this machine do X" without re-deriving
+// the GPU/kernel/compositor logic themselves.
+type Features struct {
+	SupportsVulkan            bool
+	SupportsVKD3D             bool
+	SupportsRayTracing        bool
+	SupportsHDR               bool
+	SupportsVRR               bool
+	SupportsHiDPI             bool
+	SupportsGamescopeMangoapp bool
+	SupportsHDRMetadata       bool
+	SupportsWayland           bool
+	SupportsPipeWire          bool
+	MeetsSteamMinSpec         bool
+	MeetsSteamRecommendedSpec bool
+
+	reasons map[FeatureName]string
+}
+
+// DeriveFeatures builds a Features matrix from a collected SystemInfo
+// snapshot. Every feature is paired with a reason, recorded whether it's
+// supported or not, so Explain() can tell the user why as well as what.
+func DeriveFeatures(s *SystemInfo) *Features {
+	f := &Features{reasons: make(map[FeatureName]string)}
+
+	f.SupportsWayland = s.Desktop.IsWayland
+	f.set(FeatureWayland, wifPresentReason(f.SupportsWayland))
+
+	f.SupportsPipeWire = pipewireAvailable()
+	f.set(FeaturePipeWire, pipewireReason(f.SupportsPipeWire))
+
+	f.deriveVulkan(s)
+	f.deriveVKD3D(s)
+	f.deriveRayTracing(s)
+	f.deriveHDR(s)
+	f.deriveHDRMetadata(s)
+	f.deriveVRR(s)
+	f.deriveHiDPI(s)
+	f.deriveGamescopeMangoapp(s)
+	f.deriveSteamSpecs(s)
+
+	return f
+}
+
+func (f *Features) set(name FeatureName, reason string) {
+	f.reasons[name] = reason
+}
+
+func (f *Features) deriveVulkan(s *SystemInfo) {
+	nvidia := s.GPU.ByVendor("nvidia")
+	switch {
+	case nvidia != nil && nvidia.DriverVersion != "":
+		f.SupportsVulkan = true
+		f.set(FeatureVulkan, "NVIDIA driver "+nvidia.DriverVersion+" ships Vulkan")
+	case s.GPU.HasAMD():
+		f.SupportsVulkan = true
+		f.set(FeatureVulkan, "AMD GPU detected, Mesa RADV ships Vulkan")
+	case s.GPU.HasIntel():
+		f.SupportsVulkan = true
+		f.set(FeatureVulkan, "Intel GPU detected, Mesa ANV ships Vulkan")
+	default:
+		f.SupportsVulkan = false
+		f.set(FeatureVulkan, "no GPU with a Vulkan driver detected")
+	}
+}
+
+func (f *Features) deriveVKD3D(s *SystemInfo) {
+	if !f.SupportsVulkan {
+		f.SupportsVKD3D = false
+		f.set(FeatureVKD3D, "vkd3d-proton requires Vulkan: "+f.reasons[FeatureVulkan])
+		return
+	}
+	if nvidia := s.GPU.ByVendor("nvidia"); nvidia != nil {
+		major := parseVersionParts(nvidia.DriverVersion)
+		if len(major) > 0 && major[0] < 470 {
+			f.SupportsVKD3D = false
+			f.set(FeatureVKD3D, fmt.Sprintf("NVIDIA driver %s <470, too old for vkd3d-proton", nvidia.DriverVersion))
+			return
+		}
+	}
+	f.SupportsVKD3D = true
+	f.set(FeatureVKD3D, "Vulkan 1.2+ capable GPU present for vkd3d-proton")
+}
+
+func (f *Features) deriveRayTracing(s *SystemInfo) {
+	if len(s.GPU.GPUs) == 0 {
+		f.SupportsRayTracing = false
+		f.set(FeatureRayTracing, "no GPU detected")
+		return
+	}
+
+	for _, gpu := range s.GPU.GPUs {
+		name := strings.ToUpper(gpu.Name)
+		switch {
+		case gpu.VendorName == "nvidia" && strings.Contains(name, "RTX"):
+			f.SupportsRayTracing = true
+			f.set(FeatureRayTracing, "NVIDIA RTX GPU has hardware ray tracing")
+			return
+		case gpu.VendorName == "amd" && (strings.Contains(name, "RX 6") || strings.Contains(name, "RX 7")):
+			f.SupportsRayTracing = true
+			f.set(FeatureRayTracing, "AMD RDNA2+ GPU has hardware ray tracing")
+			return
+		}
+	}
+
+	f.SupportsRayTracing = false
+	f.set(FeatureRayTracing, "no GPU with ray tracing hardware detected")
+}
+
+func (f *Features) deriveHDR(s *SystemInfo) {
+	kernel := parseVersionParts(s.OS.Kernel)
+	if !versionAtLeast(kernel, 6, 8) {
+		f.SupportsHDR = false
+		f.set(FeatureHDR, "kernel "+firstField(s.OS.Kernel)+" <6.8")
+		return
+	}
+	if s.Desktop.Compositor != "Hyprland" {
+		f.SupportsHDR = false
+		f.set(FeatureHDR, "compositor "+orUnknown(s.Desktop.Compositor)+" is not Hyprland")
+		return
+	}
+	hyprVersion := parseVersionParts(s.Desktop.Version)
+	if !versionAtLeast(hyprVersion, 0, 41) {
+		f.SupportsHDR = false
+		f.set(FeatureHDR, "Hyprland "+orUnknown(s.Desktop.Version)+" <0.41")
+		return
+	}
+	if s.Display.Count == 0 {
+		f.SupportsHDR = false
+		f.set(FeatureHDR, "no HDR-capable monitor detected")
+		return
+	}
+	f.SupportsHDR = true
+	f.set(FeatureHDR, "kernel 6.8+, Hyprland 0.41+, and a display present")
+}
+
+// deriveHDRMetadata covers HDR metadata passthrough (color space, peak
+// brightness) which additionally needs gamescope to shuttle the metadata
+// from the game to the compositor.
+func (f *Features) deriveHDRMetadata(s *SystemInfo) {
+	if !f.SupportsHDR {
+		f.SupportsHDRMetadata = false
+		f.set(FeatureHDRMetadata, "HDR metadata requires HDR: "+f.reasons[FeatureHDR])
+		return
+	}
+	if !s.Dependencies.Gamescope.Installed {
+		f.SupportsHDRMetadata = false
+		f.set(FeatureHDRMetadata, "gamescope is not installed to pass through HDR metadata")
+		return
+	}
+	f.SupportsHDRMetadata = true
+	f.set(FeatureHDRMetadata, "HDR supported and gamescope installed to pass through metadata")
+}
+
+func (f *Features) deriveVRR(s *SystemInfo) {
+	if s.Display.Count == 0 {
+		f.SupportsVRR = false
+		f.set(FeatureVRR, "no display detected")
+		return
+	}
+	if s.Desktop.Compositor != "Hyprland" {
+		f.SupportsVRR = false
+		f.set(FeatureVRR, "compositor "+orUnknown(s.Desktop.Compositor)+" is not Hyprland")
+		return
+	}
+	if !s.GPU.HasAMD() && !s.GPU.HasNVIDIA() {
+		f.SupportsVRR = false
+		f.set(FeatureVRR, "no discrete GPU with adaptive sync support detected")
+		return
+	}
+	f.SupportsVRR = true
+	f.set(FeatureVRR, "Hyprland with a discrete GPU and display present")
+}
+
+func (f *Features) deriveHiDPI(s *SystemInfo) {
+	if s.Display.Primary.Scale > 1 {
+		f.SupportsHiDPI = true
+		f.set(FeatureHiDPI, fmt.Sprintf("primary display scaled at %.2fx", s.Display.Primary.Scale))
+		return
+	}
+	f.SupportsHiDPI = false
+	f.set(FeatureHiDPI, "no display scaling >1x detected")
+}
+
+func (f *Features) deriveGamescopeMangoapp(s *SystemInfo) {
+	if !s.Dependencies.Gamescope.Installed {
+		f.SupportsGamescopeMangoapp = false
+		f.set(FeatureGamescopeMangoapp, "gamescope is not installed")
+		return
+	}
+	gamescopeVersion := parseVersionParts(s.Dependencies.Gamescope.Version)
+	if !versionAtLeast(gamescopeVersion, 3, 14) {
+		f.SupportsGamescopeMangoapp = false
+		f.set(FeatureGamescopeMangoapp, "gamescope "+orUnknown(s.Dependencies.Gamescope.Version)+" <3.14, predates built-in mangoapp")
+		return
+	}
+	if !s.Dependencies.MangoHUD.Installed {
+		f.SupportsGamescopeMangoapp = false
+		f.set(FeatureGamescopeMangoapp, "mangohud is not installed")
+		return
+	}
+	f.SupportsGamescopeMangoapp = true
+	f.set(FeatureGamescopeMangoapp, "gamescope 3.14+ and mangohud both installed")
+}
+
+func (f *Features) deriveSteamSpecs(s *SystemInfo) {
+	hasGPU := s.GPU.HasNVIDIA() || s.GPU.HasAMD() || s.GPU.HasIntel()
+
+	switch {
+	case s.Memory.TotalMiB < 4*1024:
+		f.set(FeatureSteamMinSpec, "less than 4GiB RAM")
+	case s.CPU.Cores < 2:
+		f.set(FeatureSteamMinSpec, "fewer than 2 CPU cores")
+	case !hasGPU:
+		f.set(FeatureSteamMinSpec, "no GPU detected")
+	default:
+		f.MeetsSteamMinSpec = true
+		f.set(FeatureSteamMinSpec, "4GiB+ RAM, 2+ cores, and a GPU present")
+	}
+
+	switch {
+	case s.Memory.TotalMiB < 8*1024:
+		f.set(FeatureSteamRecommended, "less than 8GiB RAM")
+	case s.CPU.Cores < 4:
+		f.set(FeatureSteamRecommended, "fewer than 4 CPU cores")
+	case !s.GPU.HasNVIDIA() && !s.GPU.HasAMD():
+		f.set(FeatureSteamRecommended, "no discrete GPU detected")
+	default:
+		f.MeetsSteamRecommendedSpec = true
+		f.set(FeatureSteamRecommended, "8GiB+ RAM, 4+ cores, and a discrete GPU present")
+	}
+}
+
+// Requires reports whether feature is supported, along with the reason
+// recorded for that verdict.
+func (f *Features) Requires(feature FeatureName) (bool, string) {
+	return f.supports(feature), f.reasons[feature]
+}
+
+// Explain returns the reason recorded for every feature, supported or not,
+// so callers like the wizard UI can tell users why a feature is unavailable.
+func (f *Features) Explain() map[FeatureName]string {
+	explained := make(map[FeatureName]string, len(f.reasons))
+	for k, v := range f.reasons {
+		explained[k] = v
+	}
+	return explained
+}
+
+func (f *Features) supports(feature FeatureName) bool {
+	switch feature {
+	case FeatureVulkan:
+		return f.SupportsVulkan
+	case FeatureVKD3D:
+		return f.SupportsVKD3D
+	case FeatureRayTracing:
+		return f.SupportsRayTracing
+	case FeatureHDR:
+		return f.SupportsHDR
+	case FeatureVRR:
+		return f.SupportsVRR
+	case FeatureHiDPI:
+		return f.SupportsHiDPI
+	case FeatureGamescopeMangoapp:
+		return f.SupportsGamescopeMangoapp
+	case FeatureHDRMetadata:
+		return f.SupportsHDRMetadata
+	case FeatureWayland:
+		return f.SupportsWayland
+	case FeaturePipeWire:
+		return f.SupportsPipeWire
+	case FeatureSteamMinSpec:
+		return f.MeetsSteamMinSpec
+	case FeatureSteamRecommended:
+		return f.MeetsSteamRecommendedSpec
+	default:
+		return false
+	}
+}
+
+func pipewireAvailable() bool {
+	_, err := exec.LookPath("pipewire")
+	return err == nil
+}
+
+func pipewireReason(supported bool) string {
+	if supported {
+		return "pipewire binary found on PATH"
+	}
+	return "pipewire is not installed"
+}
+
+func wifPresentReason(supported bool) string {
+	if supported {
+		return "WAYLAND_DISPLAY is set"
+	}
+	return "WAYLAND_DISPLAY is not set"
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func firstField(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return strings.Fields(s)[0]
+}
+
+// parseVersionParts extracts the leading dot-separated numeric components
+// from a version string, e.g. "6.8.2-arch1-1" -> [6, 8, 2], or "v0.41.2" ->
+// [0, 41, 2]. Non-numeric trailing suffixes are ignored.
+func parseVersionParts(s string) []int {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	var numeric strings.Builder
+	for _, c := range s {
+		if (c >= '0' && c <= '9') || c == '.' {
+			numeric.WriteRune(c)
+		} else {
+			break
+		}
+	}
+
+	var parts []int
+	for _, field := range strings.Split(numeric.String(), ".") {
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// versionAtLeast reports whether parts is >= the given major.minor.
+func versionAtLeast(parts []int, major, minor int) bool {
+	if len(parts) == 0 {
+		return false
+	}
+	if parts[0] != major {
+		return parts[0] > major
+	}
+	if len(parts) < 2 {
+		return minor == 0
+	}
+	return parts[1] >= minor
+}
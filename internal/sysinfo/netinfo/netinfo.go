@@ -0,0 +1,293 @@
+// Package netinfo enumerates network interfaces and probes connectivity for
+// sysinfo. It's a separate package (not just a function in sysinfo.go)
+// because unlike the rest of sysinfo's one-shot sysfs reads, a connectivity
+// probe has its own timeout/racing concerns worth isolating and testing on
+// their own.
+package netinfo
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status is the overall verdict of a connectivity probe.
+type Status string
+
+const (
+	StatusOnline              Status = "Online"
+	StatusCaptivePortal       Status = "CaptivePortal"
+	StatusLimitedConnectivity Status = "LimitedConnectivity"
+	StatusOffline             Status = "Offline"
+)
+
+// Interface describes one network interface and, where available, its
+// NetworkManager/systemd-networkd state and WiFi details.
+type Interface struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"` // "ethernet", "wifi", "loopback", "other"
+	State      string   `json:"state,omitempty"`
+	HasCarrier bool     `json:"has_carrier"`
+	MAC        string   `json:"mac,omitempty"`
+	IPs        []string `json:"ips,omitempty"`
+	Connection string   `json:"connection,omitempty"` // NM/networkd connection name
+	SSID       string   `json:"ssid,omitempty"`
+	Signal     int      `json:"signal,omitempty"` // percent, wifi only
+	Security   string   `json:"security,omitempty"`
+}
+
+// Info is a full network snapshot: every interface plus an overall
+// connectivity verdict.
+type Info struct {
+	Status     Status      `json:"status"`
+	Interfaces []Interface `json:"interfaces,omitempty"`
+}
+
+// Primary returns the first non-loopback interface that's up, or nil if
+// none are.
+func (i Info) Primary() *Interface {
+	for idx := range i.Interfaces {
+		iface := &i.Interfaces[idx]
+		if iface.Type != "loopback" && (iface.State == "up" || iface.State == "routable" || iface.HasCarrier) {
+			return iface
+		}
+	}
+	return nil
+}
+
+// connectivityTargets are raced for L3/L4 reachability before bothering with
+// an HTTP captive-portal probe, so one blocked/unreachable target doesn't
+// cost more than the timeout below.
+var connectivityTargets = []string{
+	"1.1.1.1:443",
+	"8.8.8.8:443",
+	"9.9.9.9:443",
+}
+
+const connectivityTimeout = 1 * time.Second
+
+// Collect enumerates every network interface and probes connectivity,
+// preferring NetworkManager, then systemd-networkd, for per-device state and
+// WiFi details.
+func Collect() Info {
+	return Info{
+		Interfaces: collectInterfaces(),
+		Status:     CheckConnectivity(),
+	}
+}
+
+func collectInterfaces() []Interface {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	var result []Interface
+	for _, iface := range ifaces {
+		device := Interface{
+			Name: iface.Name,
+			MAC:  iface.HardwareAddr.String(),
+		}
+		switch {
+		case iface.Flags&net.FlagLoopback != 0:
+			device.Type = "loopback"
+		case isWireless(iface.Name):
+			device.Type = "wifi"
+		default:
+			device.Type = "ethernet"
+		}
+		device.State = strings.TrimSpace(readSysfs(iface.Name, "operstate"))
+		device.HasCarrier = strings.TrimSpace(readSysfs(iface.Name, "carrier")) == "1"
+		if addrs, err := iface.Addrs(); err == nil {
+			for _, addr := range addrs {
+				if ipNet, ok := addr.(*net.IPNet); ok {
+					device.IPs = append(device.IPs, ipNet.IP.String())
+				}
+			}
+		}
+		result = append(result, device)
+	}
+
+	switch {
+	case nmcliAvailable():
+		applyNMCLI(result)
+	case networkctlAvailable():
+		applyNetworkctl(result)
+	}
+
+	return result
+}
+
+func isWireless(name string) bool {
+	_, err := os.Stat("/sys/class/net/" + name + "/wireless")
+	return err == nil
+}
+
+func readSysfs(iface, attr string) string {
+	data, err := os.ReadFile("/sys/class/net/" + iface + "/" + attr)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func nmcliAvailable() bool {
+	_, err := exec.LookPath("nmcli")
+	return err == nil
+}
+
+func networkctlAvailable() bool {
+	_, err := exec.LookPath("networkctl")
+	return err == nil
+}
+
+// applyNMCLI augments result in place with NetworkManager's view of device
+// state/connection, plus SSID/signal/security for the active WiFi AP.
+func applyNMCLI(result []Interface) {
+	byName := make(map[string]*Interface, len(result))
+	for i := range result {
+		byName[result[i].Name] = &result[i]
+	}
+
+	if out, err := exec.Command("nmcli", "-t", "-f", "DEVICE,TYPE,STATE,CONNECTION", "device").Output(); err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			fields := strings.Split(line, ":")
+			if len(fields) < 4 {
+				continue
+			}
+			if device, ok := byName[fields[0]]; ok {
+				device.State = fields[2]
+				device.Connection = fields[3]
+			}
+		}
+	}
+
+	out, err := exec.Command("nmcli", "-t", "-f", "active,ssid,signal,security,device", "dev", "wifi").Output()
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < 5 || fields[0] != "yes" {
+			continue
+		}
+		device, ok := byName[fields[4]]
+		if !ok {
+			continue
+		}
+		device.SSID = fields[1]
+		device.Signal, _ = strconv.Atoi(fields[2])
+		device.Security = fields[3]
+	}
+}
+
+// applyNetworkctl augments result in place using systemd-networkd's view,
+// for systems without NetworkManager.
+func applyNetworkctl(result []Interface) {
+	out, err := exec.Command("networkctl", "--json=short", "list").Output()
+	if err != nil {
+		return
+	}
+
+	var entries []struct {
+		Name             string `json:"Name"`
+		OperationalState string `json:"OperationalState"`
+		SetupState       string `json:"SetupState"`
+	}
+	if err := json.Unmarshal(out, &entries); err != nil {
+		return
+	}
+
+	byName := make(map[string]*Interface, len(result))
+	for i := range result {
+		byName[result[i].Name] = &result[i]
+	}
+	for _, e := range entries {
+		device, ok := byName[e.Name]
+		if !ok {
+			continue
+		}
+		if e.OperationalState != "" {
+			device.State = e.OperationalState
+		}
+		device.Connection = e.SetupState
+	}
+}
+
+// CheckConnectivity races a TCP connect against several well-known hosts to
+// confirm L3/L4 reachability without blocking 5s on an offline system, then
+// checks for a captive portal by comparing the response against the
+// well-known 204 endpoint.
+func CheckConnectivity() Status {
+	if !raceTCP(connectivityTargets, connectivityTimeout) {
+		return StatusOffline
+	}
+	return probeCaptivePortal()
+}
+
+// raceTCP reports whether any of targets accepted a TCP connection within
+// timeout.
+func raceTCP(targets []string, timeout time.Duration) bool {
+	result := make(chan bool, len(targets))
+	for _, target := range targets {
+		go func(t string) {
+			conn, err := net.DialTimeout("tcp", t, timeout)
+			if err != nil {
+				result <- false
+				return
+			}
+			conn.Close()
+			result <- true
+		}(target)
+	}
+
+	deadline := time.After(timeout)
+	for range targets {
+		select {
+		case ok := <-result:
+			if ok {
+				return true
+			}
+		case <-deadline:
+			return false
+		}
+	}
+	return false
+}
+
+// probeCaptivePortal distinguishes a clean connection from one intercepted
+// by a captive portal: a genuine connection gets the expected bare 204, a
+// captive portal redirects or substitutes a login page.
+func probeCaptivePortal() Status {
+	client := &http.Client{
+		Timeout: connectivityTimeout + 500*time.Millisecond,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get("http://connectivitycheck.gstatic.com/generate_204")
+	if err != nil {
+		return StatusLimitedConnectivity
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return StatusOnline
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return StatusCaptivePortal
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if len(body) > 0 {
+			return StatusCaptivePortal
+		}
+		return StatusLimitedConnectivity
+	}
+}
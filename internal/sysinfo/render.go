@@ -0,0 +1,218 @@
+package sysinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wattfource/wizado/internal/license"
+)
+
+// Render writes info to w in the given format, for scripted/headless
+// consumers that don't want the TUI's styled viewSystemInfo rendering:
+//
+//   - "json" - indented encoding/json, same bytes as ToJSON.
+//   - "yaml" - a YAML-subset rendering of the same structure.
+//   - "prom" - a Prometheus textfile-collector exposition, suitable for
+//     node_exporter's --collector.textfile.directory.
+//
+// Any other format is reported as an error rather than silently falling
+// back to one of the above.
+func Render(info *SystemInfo, format string, w io.Writer) error {
+	switch format {
+	case "json":
+		data, err := info.ToJSON()
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	case "yaml":
+		return renderYAML(info, w)
+	case "prom":
+		return renderProm(info, w)
+	default:
+		return fmt.Errorf("sysinfo: unknown render format %q (want json, yaml, or prom)", format)
+	}
+}
+
+// renderYAML re-decodes info's JSON form into generic map/slice/scalar
+// values and walks that, rather than reflecting over SystemInfo directly -
+// one recursive function then handles every field without a YAML library.
+func renderYAML(info *SystemInfo, w io.Writer) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	writeYAMLMap(&b, 0, generic.(map[string]interface{}))
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// writeYAMLValue writes v as the remainder of an already-started "key:"
+// line: a scalar stays inline, while a non-empty map or slice starts a new
+// indented block on the following line.
+func writeYAMLValue(b *strings.Builder, indent int, v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n")
+		writeYAMLMap(b, indent, val)
+	case []interface{}:
+		if len(val) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		writeYAMLSlice(b, indent, val)
+	default:
+		b.WriteString(" ")
+		b.WriteString(yamlScalar(val))
+		b.WriteString("\n")
+	}
+}
+
+// writeYAMLMap writes m's keys in sorted order (json.Unmarshal loses Go
+// struct field order, so a stable sort is the only way to get repeatable
+// output), each indented by indent levels of two spaces.
+func writeYAMLMap(b *strings.Builder, indent int, m map[string]interface{}) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeYAMLIndent(b, indent)
+		b.WriteString(k)
+		b.WriteString(":")
+		writeYAMLValue(b, indent+1, m[k])
+	}
+}
+
+// writeYAMLSlice writes items as "- " block entries. A nested map or slice
+// starts on the line after the dash, indented one level further - valid
+// YAML, if not the most compact rendering.
+func writeYAMLSlice(b *strings.Builder, indent int, items []interface{}) {
+	for _, item := range items {
+		writeYAMLIndent(b, indent)
+		switch val := item.(type) {
+		case map[string]interface{}, []interface{}:
+			b.WriteString("-")
+			writeYAMLValue(b, indent+1, val)
+		default:
+			b.WriteString("- ")
+			b.WriteString(yamlScalar(val))
+			b.WriteString("\n")
+		}
+	}
+}
+
+func writeYAMLIndent(b *strings.Builder, indent int) {
+	b.WriteString(strings.Repeat("  ", indent))
+}
+
+// yamlScalar renders a JSON scalar (string, float64, bool, nil) as YAML
+// plain text, quoting strings only when needed to avoid ambiguity with
+// YAML's own syntax.
+func yamlScalar(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case string:
+		if yamlNeedsQuoting(val) {
+			return strconv.Quote(val)
+		}
+		return val
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// yamlNeedsQuoting reports whether s would be misread as something other
+// than a plain string by a YAML parser - empty, padded with whitespace,
+// one of the reserved scalars, numeric, or containing a character that's
+// part of YAML's own syntax.
+func yamlNeedsQuoting(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch s {
+	case "true", "false", "null", "~":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	return strings.ContainsAny(s, ":#\"'[]{}\n")
+}
+
+// renderProm writes info (plus the current license status) as Prometheus
+// textfile-collector gauges, so a node_exporter textfile directory can
+// scrape basic wizado diagnostics without a custom exporter.
+func renderProm(info *SystemInfo, w io.Writer) error {
+	fmt.Fprintln(w, "# HELP wizado_display_refresh_hz Refresh rate of the primary display, in Hz.")
+	fmt.Fprintln(w, "# TYPE wizado_display_refresh_hz gauge")
+	fmt.Fprintf(w, "wizado_display_refresh_hz %s\n", strconv.FormatFloat(info.Display.Primary.RefreshHz, 'g', -1, 64))
+
+	fmt.Fprintln(w, "# HELP wizado_memory_total_mib Total system RAM, in MiB.")
+	fmt.Fprintln(w, "# TYPE wizado_memory_total_mib gauge")
+	fmt.Fprintf(w, "wizado_memory_total_mib %d\n", info.Memory.TotalMiB)
+
+	fmt.Fprintln(w, "# HELP wizado_dep_installed Whether a required dependency is installed (1) or not (0).")
+	fmt.Fprintln(w, "# TYPE wizado_dep_installed gauge")
+	deps := []struct {
+		name string
+		pkg  PackageInfo
+	}{
+		{"steam", info.Dependencies.Steam},
+		{"gamescope", info.Dependencies.Gamescope},
+		{"gamemode", info.Dependencies.GameMode},
+		{"mangohud", info.Dependencies.MangoHUD},
+		{"hyprland", info.Dependencies.Hyprland},
+	}
+	for _, dep := range deps {
+		fmt.Fprintf(w, "wizado_dep_installed{name=%q} %s\n", dep.name, promBool(dep.pkg.Installed))
+	}
+
+	fmt.Fprintln(w, "# HELP wizado_license_status Current license status (1 for the active status, 0 otherwise), by status value.")
+	fmt.Fprintln(w, "# TYPE wizado_license_status gauge")
+	current := license.Check().Status
+	for _, status := range []license.Status{
+		license.StatusValid,
+		license.StatusOfflineGrace,
+		license.StatusNoLicense,
+		license.StatusInvalid,
+		license.StatusExpired,
+		license.StatusMachineMismatch,
+		license.StatusOfflineExpired,
+		license.StatusTampered,
+		license.StatusClockTampered,
+	} {
+		fmt.Fprintf(w, "wizado_license_status{status=%q} %s %d\n", status, promBool(status == current), info.CollectedAt.UnixMilli())
+	}
+
+	return nil
+}
+
+func promBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
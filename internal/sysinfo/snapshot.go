@@ -0,0 +1,460 @@
+package sysinfo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wattfource/wizado/internal/sysinfo/netinfo"
+)
+
+// snapshotAuxCommands are shelled out to when building a Snapshot, each
+// written to auxDir under its arguments joined with "_" (for example
+// "hyprctl_monitors_-j.out"). Failures
+// are recorded as a ".error" file instead of aborting the snapshot - a
+// machine without hyprctl running shouldn't lose the rest of the bundle.
+var snapshotAuxCommands = [][]string{
+	{"hyprctl", "monitors", "-j"},
+	{"hyprctl", "devices", "-j"},
+	{"pacman", "-Qqe"},
+	{"journalctl", "-k", "-b", "--no-pager"},
+}
+
+// Snapshot writes a redacted, signed support bundle to dir: system.json and
+// system.txt (the JSON and Summary() renderings of s, both redacted via
+// Redact), plus copies of /etc/os-release, the kernel cmdline, loaded
+// modules, and the output of hyprctl/pacman/journalctl under an aux/
+// subdirectory - everything support usually asks a user to attach to a
+// ticket, gathered in one pass. The aux files go through the same
+// redaction as system.json/system.txt (known serial/hostname/MAC/SSID/IP
+// values, plus generic MAC-address and IPv4-address scrubs) since
+// journalctl and hyprctl output can otherwise repeat those values in plain
+// text. Once every file is written, a manifest.json/manifest.sig pair is
+// added (see signSnapshot) so VerifySnapshot can later detect the bundle
+// being tampered with after the fact. dir is created if it doesn't exist.
+func Snapshot(s *SystemInfo, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("sysinfo: creating snapshot dir %s: %w", dir, err)
+	}
+
+	redacted := s.Redact()
+	replacements := s.sensitiveReplacements()
+
+	data, err := redacted.ToJSON()
+	if err != nil {
+		return fmt.Errorf("sysinfo: marshaling snapshot: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "system.json"), data, 0644); err != nil {
+		return fmt.Errorf("sysinfo: writing system.json: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "system.txt"), []byte(redacted.Summary()), 0644); err != nil {
+		return fmt.Errorf("sysinfo: writing system.txt: %w", err)
+	}
+
+	auxDir := filepath.Join(dir, "aux")
+	if err := os.MkdirAll(auxDir, 0755); err != nil {
+		return fmt.Errorf("sysinfo: creating aux dir: %w", err)
+	}
+
+	copyFileRedacted("/etc/os-release", filepath.Join(auxDir, "os-release"), replacements)
+	copyFileRedacted("/proc/cmdline", filepath.Join(auxDir, "cmdline"), replacements)
+	copyFileRedacted("/proc/modules", filepath.Join(auxDir, "modules"), replacements)
+
+	for _, cmd := range snapshotAuxCommands {
+		name := strings.Join(cmd, "_") + ".out"
+		out, err := exec.Command(cmd[0], cmd[1:]...).Output()
+		path := filepath.Join(auxDir, name)
+		if err != nil {
+			os.WriteFile(path+".error", []byte(err.Error()), 0644)
+			continue
+		}
+		os.WriteFile(path, []byte(redactText(string(out), replacements)), 0644)
+	}
+
+	return signSnapshot(dir)
+}
+
+// snapshotManifest lists every file in a snapshot bundle along with its
+// SHA-256 checksum, so the bundle can be verified against tampering after
+// the fact.
+type snapshotManifest struct {
+	Files []manifestEntry `json:"files"`
+}
+
+// manifestEntry is one file's path (relative to the snapshot dir) and
+// SHA-256 checksum.
+type manifestEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// signSnapshot hashes every file Snapshot has written under dir into
+// manifest.json, then signs that manifest with an HMAC-SHA256 keyed by the
+// per-machine redaction key (see redactionKey) and writes the signature to
+// manifest.sig. VerifySnapshot uses the pair to detect a bundle that's been
+// edited, had a file removed, or had one added after signing.
+func signSnapshot(dir string) error {
+	var entries []manifestEntry
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		entries = append(entries, manifestEntry{Path: rel, SHA256: hex.EncodeToString(sum[:])})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("sysinfo: hashing snapshot files: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+
+	manifest, err := json.MarshalIndent(snapshotManifest{Files: entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("sysinfo: marshaling manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), manifest, 0644); err != nil {
+		return fmt.Errorf("sysinfo: writing manifest.json: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, redactionKey())
+	mac.Write(manifest)
+	sig := hex.EncodeToString(mac.Sum(nil))
+	if err := os.WriteFile(filepath.Join(dir, "manifest.sig"), []byte(sig), 0644); err != nil {
+		return fmt.Errorf("sysinfo: writing manifest.sig: %w", err)
+	}
+	return nil
+}
+
+// VerifySnapshot recomputes manifest.json from the files under dir and
+// checks it against manifest.sig, reporting whether the bundle Snapshot
+// wrote there is still intact. A false result with a nil error means the
+// signature didn't match or a file changed/went missing since signing; a
+// non-nil error means dir doesn't look like a snapshot at all (no
+// manifest.json/manifest.sig to check).
+func VerifySnapshot(dir string) (bool, error) {
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return false, fmt.Errorf("sysinfo: reading manifest.json: %w", err)
+	}
+	sig, err := os.ReadFile(filepath.Join(dir, "manifest.sig"))
+	if err != nil {
+		return false, fmt.Errorf("sysinfo: reading manifest.sig: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, redactionKey())
+	mac.Write(manifest)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimSpace(string(sig)))) {
+		return false, nil
+	}
+
+	var parsed snapshotManifest
+	if err := json.Unmarshal(manifest, &parsed); err != nil {
+		return false, fmt.Errorf("sysinfo: parsing manifest.json: %w", err)
+	}
+
+	known := make(map[string]bool, len(parsed.Files))
+	for _, entry := range parsed.Files {
+		known[entry.Path] = true
+		data, err := os.ReadFile(filepath.Join(dir, entry.Path))
+		if err != nil {
+			return false, nil
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != entry.SHA256 {
+			return false, nil
+		}
+	}
+
+	// A file present on disk but missing from the manifest (added after
+	// signing) is just as much a tamper as a changed or missing one.
+	extra := false
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "manifest.json" || rel == "manifest.sig" {
+			return nil
+		}
+		if !known[rel] {
+			extra = true
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("sysinfo: walking snapshot dir: %w", err)
+	}
+	if extra {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// copyFileRedacted copies src to dst with redactText applied, silently
+// doing nothing if src can't be read - aux capture shouldn't fail the
+// whole snapshot over an unreadable /proc file.
+func copyFileRedacted(src, dst string, replacements map[string]string) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return
+	}
+	os.WriteFile(dst, []byte(redactText(string(data), replacements)), 0644)
+}
+
+// macAddressPattern matches a colon-separated MAC address, for scrubbing
+// ones that show up in log/command text (e.g. journalctl interface
+// rename/bring-up lines) but weren't already known from s.Network.
+var macAddressPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{2}(:[0-9a-f]{2}){5}\b`)
+
+// ipAddressPattern matches a dotted-quad IPv4 address, for scrubbing ones
+// that show up in log/command text (e.g. journalctl DHCP lease lines, hyprctl
+// monitor output) but weren't already known from s.Network. It's deliberately
+// loose (no octet-range check) since over-matching a log line is harmless and
+// under-matching leaks an address.
+var ipAddressPattern = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}\b`)
+
+// sensitiveReplacements returns every raw identifying value known from s
+// (board serial, hostname, interface MACs/SSIDs/IPs) mapped to its redacted
+// form, for substituting into aux command/file output that isn't structured
+// enough for Redact's field-by-field approach.
+func (s *SystemInfo) sensitiveReplacements() map[string]string {
+	replacements := make(map[string]string)
+	if s.Board.Serial != "" {
+		replacements[s.Board.Serial] = redactValue(s.Board.Serial)
+	}
+	if s.OS.Hostname != "" {
+		replacements[s.OS.Hostname] = redactValue(s.OS.Hostname)
+	}
+	for _, iface := range s.Network.Interfaces {
+		if iface.MAC != "" {
+			replacements[iface.MAC] = redactValue(iface.MAC)
+		}
+		if iface.SSID != "" {
+			replacements[iface.SSID] = redactValue(iface.SSID)
+		}
+		for _, ip := range iface.IPs {
+			replacements[ip] = redactValue(ip)
+		}
+	}
+	return replacements
+}
+
+// redactText replaces every known sensitive value in text with its
+// redacted form, then scrubs any remaining MAC- or IPv4-address-like pattern
+// the same way - a best-effort pass over unstructured command/log output,
+// not a guarantee every identifying detail is caught.
+func redactText(text string, replacements map[string]string) string {
+	for raw, redacted := range replacements {
+		text = strings.ReplaceAll(text, raw, redacted)
+	}
+	text = macAddressPattern.ReplaceAllStringFunc(text, redactValue)
+	return ipAddressPattern.ReplaceAllStringFunc(text, redactValue)
+}
+
+// redactionSalt caches the per-machine HMAC key derived from
+// /etc/machine-id, so repeated Redact calls on the same machine produce
+// the same redacted value for the same input (stable across a snapshot's
+// system.json and system.txt, and across successive snapshots for diffing)
+// without ever storing the real value in the bundle.
+var redactionSalt []byte
+
+func redactionKey() []byte {
+	if redactionSalt != nil {
+		return redactionSalt
+	}
+	data, err := os.ReadFile("/etc/machine-id")
+	if err != nil {
+		// No machine-id available - fall back to a fixed key rather than
+		// failing. Bundles from this host still have stable redaction
+		// internally, just not salted against other hosts.
+		data = []byte("wizado-sysinfo-redaction-fallback")
+	}
+	redactionSalt = []byte(strings.TrimSpace(string(data)))
+	return redactionSalt
+}
+
+// redactValue replaces v with a short, stable HMAC-SHA256 of v keyed by the
+// per-machine salt - the same input always redacts to the same output on
+// one machine (so a diff between two snapshots still lines up), but the
+// original value can't be recovered without the salt.
+func redactValue(v string) string {
+	if v == "" {
+		return v
+	}
+	mac := hmac.New(sha256.New, redactionKey())
+	mac.Write([]byte(v))
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// Redact returns a copy of s with SSID, MAC address, IP address, hostname,
+// and serial number fields replaced by a stable HMAC of their original
+// value, so a snapshot can be shared with support without leaking
+// identifying details.
+func (s *SystemInfo) Redact() *SystemInfo {
+	redacted := *s
+
+	if redacted.Board.Serial != "" {
+		redacted.Board.Serial = redactValue(redacted.Board.Serial)
+	}
+	if redacted.OS.Hostname != "" {
+		redacted.OS.Hostname = redactValue(redacted.OS.Hostname)
+	}
+
+	if len(s.Network.Interfaces) > 0 {
+		ifaces := make([]netinfo.Interface, len(s.Network.Interfaces))
+		copy(ifaces, s.Network.Interfaces)
+		for i := range ifaces {
+			if ifaces[i].MAC != "" {
+				ifaces[i].MAC = redactValue(ifaces[i].MAC)
+			}
+			if ifaces[i].SSID != "" {
+				ifaces[i].SSID = redactValue(ifaces[i].SSID)
+			}
+			if len(ifaces[i].IPs) > 0 {
+				ips := make([]string, len(ifaces[i].IPs))
+				for j, ip := range ifaces[i].IPs {
+					ips[j] = redactValue(ip)
+				}
+				ifaces[i].IPs = ips
+			}
+		}
+		redacted.Network.Interfaces = ifaces
+	}
+
+	return &redacted
+}
+
+// DiffEntry is one changed, added, or removed leaf in a Diff, identified by
+// its dotted path into the JSON tree (e.g. "gpu.gpus.0.driver_version").
+type DiffEntry struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Diff is a structured comparison of two snapshots' system.json trees, for
+// regression reports: new/removed dependencies, driver-version changes,
+// resolution/refresh changes, and anything else that differs between runs.
+type Diff struct {
+	Added   []DiffEntry `json:"added,omitempty"`
+	Removed []DiffEntry `json:"removed,omitempty"`
+	Changed []DiffEntry `json:"changed,omitempty"`
+}
+
+// DiffSnapshots compares the system.json written by Snapshot in snapshot
+// directories a and b, returning every field that was added, removed, or
+// changed between them.
+func DiffSnapshots(a, b string) (Diff, error) {
+	treeA, err := loadSnapshotTree(a)
+	if err != nil {
+		return Diff{}, err
+	}
+	treeB, err := loadSnapshotTree(b)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	var d Diff
+	diffGeneric("", treeA, treeB, &d)
+	return d, nil
+}
+
+// loadSnapshotTree reads and JSON-decodes the system.json written by
+// Snapshot in dir into generic map/slice/scalar values.
+func loadSnapshotTree(dir string) (interface{}, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "system.json"))
+	if err != nil {
+		return nil, fmt.Errorf("sysinfo: reading %s: %w", filepath.Join(dir, "system.json"), err)
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("sysinfo: parsing %s: %w", filepath.Join(dir, "system.json"), err)
+	}
+	return tree, nil
+}
+
+// diffGeneric walks a and b in lockstep, appending a DiffEntry under path to
+// d for every key that's new, missing, or whose value differs. Maps recurse
+// key by key; anything else (scalars, slices) is compared as a whole value,
+// since sysinfo's arrays (GPUs, monitors, interfaces) are short enough that
+// a whole-slice "changed" entry is more useful than an index-wise diff.
+func diffGeneric(path string, a, b interface{}, d *Diff) {
+	mapA, aIsMap := a.(map[string]interface{})
+	mapB, bIsMap := b.(map[string]interface{})
+
+	if aIsMap && bIsMap {
+		keys := make(map[string]bool)
+		for k := range mapA {
+			keys[k] = true
+		}
+		for k := range mapB {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			va, inA := mapA[k]
+			vb, inB := mapB[k]
+			switch {
+			case !inA:
+				d.Added = append(d.Added, DiffEntry{Path: childPath, New: vb})
+			case !inB:
+				d.Removed = append(d.Removed, DiffEntry{Path: childPath, Old: va})
+			default:
+				diffGeneric(childPath, va, vb, d)
+			}
+		}
+		return
+	}
+
+	if !jsonEqual(a, b) {
+		d.Changed = append(d.Changed, DiffEntry{Path: path, Old: a, New: b})
+	}
+}
+
+// jsonEqual compares two values decoded from JSON by re-encoding them -
+// simpler and just as correct as reflect.DeepEqual here, since every value
+// in the tree is already one of JSON's own types.
+func jsonEqual(a, b interface{}) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aj) == string(bj)
+}
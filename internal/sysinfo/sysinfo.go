@@ -5,15 +5,18 @@ package sysinfo
 
 import (
 	"encoding/json"
-	"net"
-	"net/http"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/wattfource/wizado/internal/format"
+	"github.com/wattfource/wizado/internal/sysinfo/netinfo"
 )
 
 // SystemInfo contains all detected system information
@@ -25,6 +28,8 @@ type SystemInfo struct {
 	GPU     GPUInfo     `json:"gpu"`
 	Memory  MemoryInfo  `json:"memory"`
 	Display DisplayInfo `json:"display"`
+	Board   BoardInfo   `json:"board"`
+	EC      ECInfo      `json:"ec,omitempty"`
 
 	// Input Devices
 	Input InputInfo `json:"input"`
@@ -50,17 +55,65 @@ type CPUInfo struct {
 	Frequency string `json:"frequency"`
 }
 
-// GPUInfo contains GPU details
+// GPUInfo contains every detected GPU, enumerated from /sys/class/drm and
+// /sys/bus/pci/devices rather than a single shelled-out summary, so hybrid
+// graphics laptops (integrated + discrete) report correctly.
 type GPUInfo struct {
-	HasNVIDIA    bool   `json:"has_nvidia"`
-	HasAMD       bool   `json:"has_amd"`
-	HasIntel     bool   `json:"has_intel"`
-	Primary      string `json:"primary"`
-	PrimaryID    string `json:"primary_id"` // Vulkan device ID
-	DriverVersion string `json:"driver_version"`
-	VRAMMiB      int    `json:"vram_mib"`
+	GPUs []GPUDevice `json:"gpus"`
+}
+
+// GPUDevice is one GPU found under /sys/class/drm, identified by its PCI
+// address.
+type GPUDevice struct {
+	PCIAddress     string `json:"pci_address"`
+	VendorID       string `json:"vendor_id"`
+	DeviceID       string `json:"device_id"`
+	VendorName     string `json:"vendor_name"` // "nvidia", "amd", "intel", or ""
+	Name           string `json:"name,omitempty"`
+	Driver         string `json:"driver,omitempty"`
+	KernelModule   string `json:"kernel_module,omitempty"`
+	DriverVersion  string `json:"driver_version,omitempty"` // NVIDIA only, via nvidia-smi
+	VRAMMiB        int    `json:"vram_mib,omitempty"`
+	IsBootVGA      bool   `json:"is_boot_vga"`
+	IsRenderNode   bool   `json:"is_render_node,omitempty"`
+	DRMPrimaryNode string `json:"drm_primary_node,omitempty"`
+	DRMRenderNode  string `json:"drm_render_node,omitempty"`
 }
 
+// Primary returns the boot VGA device if one was found, else the first
+// enumerated GPU, else nil if none were detected.
+func (g GPUInfo) Primary() *GPUDevice {
+	for i := range g.GPUs {
+		if g.GPUs[i].IsBootVGA {
+			return &g.GPUs[i]
+		}
+	}
+	if len(g.GPUs) > 0 {
+		return &g.GPUs[0]
+	}
+	return nil
+}
+
+// ByVendor returns the first enumerated GPU with the given VendorName
+// ("nvidia", "amd", "intel"), or nil if none match.
+func (g GPUInfo) ByVendor(vendor string) *GPUDevice {
+	for i := range g.GPUs {
+		if g.GPUs[i].VendorName == vendor {
+			return &g.GPUs[i]
+		}
+	}
+	return nil
+}
+
+// HasNVIDIA reports whether any enumerated GPU is NVIDIA.
+func (g GPUInfo) HasNVIDIA() bool { return g.ByVendor("nvidia") != nil }
+
+// HasAMD reports whether any enumerated GPU is AMD.
+func (g GPUInfo) HasAMD() bool { return g.ByVendor("amd") != nil }
+
+// HasIntel reports whether any enumerated GPU is Intel.
+func (g GPUInfo) HasIntel() bool { return g.ByVendor("intel") != nil }
+
 // MemoryInfo contains RAM details
 type MemoryInfo struct {
 	TotalMiB     int `json:"total_mib"`
@@ -101,14 +154,29 @@ type InputDevice struct {
 	Path   string `json:"path,omitempty"`
 	Type   string `json:"type"`
 	Vendor string `json:"vendor,omitempty"`
+
+	// Controller fingerprint, populated for Type == "controller" when the
+	// device's (vendor, product) ID pair is found in /proc/bus/input/devices.
+	VendorID    string `json:"vendor_id,omitempty"`
+	ProductID   string `json:"product_id,omitempty"`
+	Canonical   string `json:"canonical,omitempty"` // e.g. "dualsense", "xbox360", "switch-pro"
+	Layout      string `json:"layout,omitempty"`     // "xinput", "dinput", "hid"
+	HasGyro     bool   `json:"has_gyro,omitempty"`
+	HasRumble   bool   `json:"has_rumble,omitempty"`
+	HasTouchpad bool   `json:"has_touchpad,omitempty"`
 }
 
 // NetworkInfo contains network status
 type NetworkInfo struct {
-	HasInternet    bool   `json:"has_internet"`
-	PrimaryIF      string `json:"primary_interface"`
-	ConnectionType string `json:"connection_type"` // "ethernet", "wifi", "unknown"
-	SSID           string `json:"ssid,omitempty"`  // WiFi network name if applicable
+	HasInternet bool                `json:"has_internet"`
+	Status      string              `json:"status"` // "Online", "CaptivePortal", "LimitedConnectivity", "Offline"
+	Interfaces  []netinfo.Interface `json:"interfaces,omitempty"`
+}
+
+// Primary returns the first non-loopback interface that's up, or nil if
+// none are.
+func (n NetworkInfo) Primary() *netinfo.Interface {
+	return netinfo.Info{Interfaces: n.Interfaces}.Primary()
 }
 
 // OSInfo contains operating system details
@@ -118,6 +186,7 @@ type OSInfo struct {
 	Version      string `json:"version"`
 	Kernel       string `json:"kernel"`
 	Architecture string `json:"architecture"`
+	Hostname     string `json:"hostname"`
 }
 
 // DesktopInfo contains desktop environment details
@@ -156,6 +225,8 @@ func Collect(wizadoVersion string) *SystemInfo {
 	info.GPU = collectGPU()
 	info.Memory = collectMemory()
 	info.Display = collectDisplay()
+	info.Board = collectBoard()
+	info.EC = collectEC()
 	info.Input = collectInput()
 	info.Network = collectNetwork()
 	info.OS = collectOS()
@@ -214,100 +285,150 @@ func collectCPU() CPUInfo {
 	return info
 }
 
-// collectGPU gathers GPU information
+// pciVendorNames maps a PCI vendor ID (lowercase hex, no "0x") to the
+// canonical GPU vendor name used in GPUDevice.VendorName.
+var pciVendorNames = map[string]string{
+	"10de": "nvidia",
+	"1002": "amd",
+	"1022": "amd", // AMD APU host bridge IDs occasionally show up alongside 1002
+	"8086": "intel",
+}
+
+// collectGPU enumerates every GPU under /sys/class/drm/card*, cross-
+// referencing /sys/bus/pci/devices for vendor/device/driver/boot_vga and
+// falling back to nvidia-smi for NVIDIA VRAM and driver version (the
+// proprietary driver doesn't expose mem_info_vram_total in sysfs).
 func collectGPU() GPUInfo {
 	info := GPUInfo{}
 
-	out, err := exec.Command("lspci", "-nn").Output()
+	cards, err := filepath.Glob("/sys/class/drm/card[0-9]*")
 	if err != nil {
 		return info
 	}
 
-	lspciOutput := string(out)
-	lines := strings.Split(lspciOutput, "\n")
-
-	for _, line := range lines {
-		lower := strings.ToLower(line)
-
-		// Check for NVIDIA
-		if strings.Contains(lower, "nvidia") && (strings.Contains(lower, "vga") || strings.Contains(lower, "3d")) {
-			info.HasNVIDIA = true
-			if info.Primary == "" {
-				info.Primary = extractGPUName(line)
-				// Extract Vulkan device ID [10de:XXXX]
-				if idx := strings.Index(line, "[10de:"); idx != -1 {
-					end := strings.Index(line[idx:], "]")
-					if end != -1 {
-						info.PrimaryID = strings.Trim(line[idx:idx+end+1], "[]")
-					}
-				}
-			}
+	seen := make(map[string]bool)
+	for _, card := range cards {
+		// Skip connector entries like "card0-DP-1"; only want bare card nodes.
+		if strings.Contains(filepath.Base(card), "-") {
+			continue
 		}
 
-		// Check for AMD
-		if (strings.Contains(lower, "amd") || strings.Contains(lower, "radeon")) &&
-			(strings.Contains(lower, "vga") || strings.Contains(lower, "3d")) {
-			info.HasAMD = true
-			if info.Primary == "" {
-				info.Primary = extractGPUName(line)
-			}
+		devicePath := card + "/device"
+		pciAddress := filepath.Base(resolveSymlink(devicePath))
+		if pciAddress == "" || seen[pciAddress] {
+			continue
 		}
+		seen[pciAddress] = true
 
-		// Check for Intel
-		if strings.Contains(lower, "intel") && strings.Contains(lower, "vga") {
-			info.HasIntel = true
-			// Only set as primary if no dedicated GPU found
-			if info.Primary == "" && !info.HasNVIDIA && !info.HasAMD {
-				info.Primary = extractGPUName(line)
-			}
-		}
+		device := gpuDeviceFromSysfs(card, devicePath, pciAddress)
+		info.GPUs = append(info.GPUs, device)
+	}
+
+	// Fill in NVIDIA-specific details that sysfs doesn't expose.
+	if nv := info.ByVendor("nvidia"); nv != nil {
+		populateNVIDIADetails(info.GPUs)
+	}
+
+	return info
+}
+
+// resolveSymlink follows a symlink and returns its target, or "" if path
+// isn't a symlink or can't be read.
+func resolveSymlink(path string) string {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+func gpuDeviceFromSysfs(card, devicePath, pciAddress string) GPUDevice {
+	device := GPUDevice{PCIAddress: pciAddress}
+
+	device.VendorID = strings.TrimPrefix(strings.TrimSpace(readFileOrEmpty(devicePath+"/vendor")), "0x")
+	device.DeviceID = strings.TrimPrefix(strings.TrimSpace(readFileOrEmpty(devicePath+"/device")), "0x")
+	device.VendorName = pciVendorNames[device.VendorID]
+
+	device.IsBootVGA = strings.TrimSpace(readFileOrEmpty(devicePath+"/boot_vga")) == "1"
+
+	if driverLink := resolveSymlink(devicePath + "/driver"); driverLink != "" {
+		device.Driver = filepath.Base(driverLink)
+		device.KernelModule = device.Driver
+	}
+
+	if renderNode, err := filepath.Glob(card + "/renderD*"); err == nil && len(renderNode) > 0 {
+		device.DRMRenderNode = "/dev/dri/" + filepath.Base(renderNode[0])
+		device.IsRenderNode = true
+	}
+	device.DRMPrimaryNode = "/dev/dri/" + filepath.Base(card)
+
+	switch device.KernelModule {
+	case "amdgpu":
+		device.VRAMMiB = parseSysfsBytesToMiB(readFileOrEmpty(devicePath + "/mem_info_vram_total"))
+	case "i915", "xe":
+		device.VRAMMiB = parseSysfsBytesToMiB(readFileOrEmpty(card + "/device/mem_info_vram_total"))
+	}
+
+	return device
+}
+
+// parseSysfsBytesToMiB converts a raw byte count (as found in amdgpu/i915
+// sysfs attributes) to MiB.
+func parseSysfsBytesToMiB(raw string) int {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0
 	}
+	bytes, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return int(bytes / 1024 / 1024)
+}
 
-	// Get NVIDIA driver version
-	if info.HasNVIDIA {
-		if out, err := exec.Command("nvidia-smi", "--query-gpu=driver_version", "--format=csv,noheader").Output(); err == nil {
-			info.DriverVersion = strings.TrimSpace(string(out))
+// populateNVIDIADetails fills in VRAM and driver version for every NVIDIA
+// GPU in gpus, queried once via nvidia-smi (which enumerates all NVIDIA
+// GPUs in PCI bus order matching /sys/class/drm).
+func populateNVIDIADetails(gpus []GPUDevice) {
+	driverVersion := ""
+	if out, err := exec.Command("nvidia-smi", "--query-gpu=driver_version", "--format=csv,noheader").Output(); err == nil {
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		if len(lines) > 0 {
+			driverVersion = strings.TrimSpace(lines[0])
 		}
+	}
 
-		// Get VRAM
-		if out, err := exec.Command("nvidia-smi", "--query-gpu=memory.total", "--format=csv,noheader,nounits").Output(); err == nil {
-			var vram int
-			if _, err := strings.NewReader(strings.TrimSpace(string(out))).Read([]byte{}); err == nil {
-				if n, _ := strings.NewReader(strings.TrimSpace(string(out))).Read([]byte{}); n > 0 {
-					// Parse MiB value
-					vramStr := strings.TrimSpace(string(out))
-					var v int
-					if _, err := exec.Command("echo", vramStr).Output(); err == nil {
-						// Simple parse
-						for _, c := range vramStr {
-							if c >= '0' && c <= '9' {
-								v = v*10 + int(c-'0')
-							}
-						}
-						vram = v
-					}
-				}
+	var vramMiB []int
+	var names []string
+	if out, err := exec.Command("nvidia-smi", "--query-gpu=memory.total,name", "--format=csv,noheader,nounits").Output(); err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			fields := strings.SplitN(line, ",", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			mib, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+			if err != nil {
+				continue
 			}
-			info.VRAMMiB = vram
+			vramMiB = append(vramMiB, mib)
+			names = append(names, strings.TrimSpace(fields[1]))
 		}
 	}
 
-	return info
-}
-
-// extractGPUName extracts the GPU name from lspci output
-func extractGPUName(line string) string {
-	// Format: "XX:XX.X VGA compatible controller: NVIDIA Corporation GeForce RTX 4090 [10de:2684]"
-	parts := strings.SplitN(line, ":", 3)
-	if len(parts) >= 3 {
-		name := strings.TrimSpace(parts[2])
-		// Remove the PCI ID at the end
-		if idx := strings.LastIndex(name, "["); idx != -1 {
-			name = strings.TrimSpace(name[:idx])
+	i := 0
+	for idx := range gpus {
+		if gpus[idx].VendorName != "nvidia" {
+			continue
 		}
-		return name
+		gpus[idx].DriverVersion = driverVersion
+		if i < len(vramMiB) {
+			gpus[idx].VRAMMiB = vramMiB[i]
+		}
+		if i < len(names) {
+			gpus[idx].Name = names[i]
+		}
+		i++
 	}
-	return ""
 }
 
 // collectMemory gathers memory information
@@ -514,12 +635,26 @@ func checkGameControllers(info *InputInfo) {
 			Name: "Game Controller",
 		}
 
-		// Try to get the name from sysfs
+		// Try to get the name and USB ID from sysfs
 		jsNum := strings.TrimPrefix(filepath.Base(path), "js")
-		namePath := "/sys/class/input/js" + jsNum + "/device/name"
-		if data, err := os.ReadFile(namePath); err == nil {
+		sysfsDir := "/sys/class/input/js" + jsNum + "/device"
+		if data, err := os.ReadFile(sysfsDir + "/name"); err == nil {
 			device.Name = strings.TrimSpace(string(data))
 		}
+		vendorID := strings.TrimSpace(readFileOrEmpty(sysfsDir + "/id/vendor"))
+		productID := strings.TrimSpace(readFileOrEmpty(sysfsDir + "/id/product"))
+		if vendorID != "" && productID != "" {
+			device.VendorID = strings.ToLower(vendorID)
+			device.ProductID = strings.ToLower(productID)
+			device.Vendor = usbVendors[device.VendorID]
+			if profile, ok := canonicalizeController(device.VendorID, device.ProductID); ok {
+				device.Canonical = profile.Canonical
+				device.Layout = profile.Layout
+				device.HasGyro = profile.HasGyro
+				device.HasRumble = profile.HasRumble
+				device.HasTouchpad = profile.HasTouchpad
+			}
+		}
 
 		info.Controllers = append(info.Controllers, device)
 	}
@@ -548,10 +683,12 @@ func checkGameControllers(info *InputInfo) {
 						}
 					}
 					if !found {
-						info.Controllers = append(info.Controllers, InputDevice{
+						device := InputDevice{
 							Name: name,
 							Type: "controller",
-						})
+						}
+						fingerprintController(&device, block)
+						info.Controllers = append(info.Controllers, device)
 					}
 				}
 			}
@@ -559,49 +696,25 @@ func checkGameControllers(info *InputInfo) {
 	}
 }
 
-// collectNetwork gathers network information
-func collectNetwork() NetworkInfo {
-	info := NetworkInfo{}
-
-	// Check internet connectivity
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Get("http://connectivitycheck.gstatic.com/generate_204")
-	if err == nil {
-		resp.Body.Close()
-		info.HasInternet = resp.StatusCode == 204
-	}
-
-	// Find primary interface
-	out, err := exec.Command("ip", "route", "get", "1.1.1.1").Output()
-	if err == nil {
-		// Parse "dev ethX" from output
-		fields := strings.Fields(string(out))
-		for i, f := range fields {
-			if f == "dev" && i+1 < len(fields) {
-				info.PrimaryIF = fields[i+1]
-				break
-			}
-		}
+func readFileOrEmpty(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
 	}
+	return string(data)
+}
 
-	// Determine connection type
-	if info.PrimaryIF != "" {
-		lower := strings.ToLower(info.PrimaryIF)
-		if strings.HasPrefix(lower, "wl") || strings.HasPrefix(lower, "wifi") {
-			info.ConnectionType = "wifi"
-			// Try to get SSID
-			out, err := exec.Command("iwgetid", "-r").Output()
-			if err == nil {
-				info.SSID = strings.TrimSpace(string(out))
-			}
-		} else if strings.HasPrefix(lower, "eth") || strings.HasPrefix(lower, "en") {
-			info.ConnectionType = "ethernet"
-		} else {
-			info.ConnectionType = "unknown"
-		}
+// collectNetwork gathers network information: every interface (with
+// NetworkManager/systemd-networkd state and WiFi details where available)
+// plus a layered connectivity probe that distinguishes offline from a
+// captive portal.
+func collectNetwork() NetworkInfo {
+	net := netinfo.Collect()
+	return NetworkInfo{
+		HasInternet: net.Status == netinfo.StatusOnline,
+		Status:      string(net.Status),
+		Interfaces:  net.Interfaces,
 	}
-
-	return info
 }
 
 // collectOS gathers operating system information
@@ -610,6 +723,10 @@ func collectOS() OSInfo {
 		Architecture: runtime.GOARCH,
 	}
 
+	if hostname, err := os.Hostname(); err == nil {
+		info.Hostname = hostname
+	}
+
 	// Get kernel version
 	if out, err := exec.Command("uname", "-r").Output(); err == nil {
 		info.Kernel = strings.TrimSpace(string(out))
@@ -782,35 +899,19 @@ func extractVersion(output string) string {
 	return ""
 }
 
-// CheckInternet performs a quick internet connectivity check
+// CheckInternet performs a quick layered connectivity check (TCP race +
+// captive-portal probe), without the 5s blocking worst case of a single
+// HTTP request on an offline system.
 func CheckInternet() bool {
-	client := &http.Client{Timeout: 3 * time.Second}
-	resp, err := client.Get("http://connectivitycheck.gstatic.com/generate_204")
-	if err != nil {
-		return false
-	}
-	resp.Body.Close()
-	return resp.StatusCode == 204
+	return netinfo.CheckConnectivity() == netinfo.StatusOnline
 }
 
 // GetPrimaryMAC returns the MAC address of the primary network interface
 func GetPrimaryMAC() string {
-	out, err := exec.Command("ip", "route", "get", "1.1.1.1").Output()
-	if err != nil {
-		return ""
-	}
-
-	fields := strings.Fields(string(out))
-	for i, f := range fields {
-		if f == "dev" && i+1 < len(fields) {
-			ifaceName := fields[i+1]
-			iface, err := net.InterfaceByName(ifaceName)
-			if err == nil && len(iface.HardwareAddr) > 0 {
-				return iface.HardwareAddr.String()
-			}
-		}
+	info := netinfo.Collect()
+	if iface := info.Primary(); iface != nil {
+		return iface.MAC
 	}
-
 	return ""
 }
 
@@ -824,23 +925,37 @@ func (s *SystemInfo) Summary() string {
 	var b strings.Builder
 
 	b.WriteString("System Information\n")
-	b.WriteString("══════════════════\n\n")
+	b.WriteString("══════════════════\n")
+	if !s.CollectedAt.IsZero() {
+		b.WriteString("Collected: " + format.Default().Duration(time.Since(s.CollectedAt)) + " ago\n")
+	}
+	b.WriteString("\n")
 
 	// Hardware
 	b.WriteString("Hardware:\n")
 	b.WriteString("  CPU: " + s.CPU.Model + "\n")
-	b.WriteString("  GPU: " + s.GPU.Primary)
-	if s.GPU.DriverVersion != "" {
-		b.WriteString(" (Driver: " + s.GPU.DriverVersion + ")")
+	if gpu := s.GPU.Primary(); gpu != nil {
+		b.WriteString("  GPU: " + gpu.Name)
+		if gpu.DriverVersion != "" {
+			b.WriteString(" (Driver: " + gpu.DriverVersion + ")")
+		}
+	} else {
+		b.WriteString("  GPU: none detected")
 	}
 	b.WriteString("\n")
-	b.WriteString("  RAM: " + formatMiB(s.Memory.TotalMiB) + "\n")
+	b.WriteString("  RAM: " + format.Default().Bytes(uint64(s.Memory.TotalMiB)*1024*1024) + "\n")
+	if s.Board.Product != "" {
+		b.WriteString("  Board: " + s.Board.Vendor + " " + s.Board.Product + "\n")
+	}
 
 	// Display
 	if s.Display.Primary.Width > 0 {
+		w, h := s.Display.Primary.Width, s.Display.Primary.Height
 		b.WriteString("  Display: " + s.Display.Primary.Name + " @ ")
-		b.WriteString(strings.Repeat(" ", 0))
-		b.WriteString(formatResolution(s.Display.Primary.Width, s.Display.Primary.Height, s.Display.Primary.RefreshHz))
+		b.WriteString(format.Default().Resolution(w, h, s.Display.Primary.RefreshHz))
+		if ratio := aspectRatioLabel(w, h); ratio != "" {
+			b.WriteString(" (" + ratio + ")")
+		}
 		b.WriteString("\n")
 	}
 
@@ -873,13 +988,13 @@ func (s *SystemInfo) Summary() string {
 	if s.Network.HasInternet {
 		b.WriteString("  ✓ Internet connected")
 	} else {
-		b.WriteString("  ✗ No internet")
-	}
-	if s.Network.ConnectionType != "" {
-		b.WriteString(" via " + s.Network.ConnectionType)
+		b.WriteString("  ✗ " + s.Network.Status)
 	}
-	if s.Network.SSID != "" {
-		b.WriteString(" (" + s.Network.SSID + ")")
+	if primary := s.Network.Primary(); primary != nil {
+		b.WriteString(" via " + primary.Type)
+		if primary.SSID != "" {
+			b.WriteString(" (" + primary.SSID + ")")
+		}
 	}
 	b.WriteString("\n")
 
@@ -912,21 +1027,89 @@ func printDep(b *strings.Builder, name string, pkg PackageInfo) {
 	b.WriteString("\n")
 }
 
-func formatMiB(mib int) string {
-	if mib >= 1024 {
-		return strings.TrimRight(strings.TrimRight(
-			strings.Replace(
-				string([]byte{byte(mib/1024/10+'0'), '.', byte(mib/1024%10+'0')}),
-				".0", "", 1),
-			"0"), ".") + " GiB"
+// ResolutionOptions controls how FormatResolution renders a display mode.
+type ResolutionOptions struct {
+	// ShowAspectRatio appends a "(16:9)"-style label derived from w:h.
+	ShowAspectRatio bool
+	// DecimalSeparator is used in the refresh rate, e.g. "." for "59.94Hz"
+	// or "," for "59,94Hz". Defaults to "." when empty.
+	DecimalSeparator string
+}
+
+// commonAspectRatios maps a reduced w:h pair to its canonical marketing name.
+var commonAspectRatios = map[[2]int]string{
+	{16, 9}:  "16:9",
+	{21, 9}:  "21:9",
+	{32, 9}:  "32:9",
+	{4, 3}:   "4:3",
+	{16, 10}: "16:10",
+	{5, 4}:   "5:4",
+}
+
+// FormatResolution renders "WxH @ HzHz" (optionally "(aspect)"), preserving
+// fractional refresh rates like 59.94Hz or 23.976Hz instead of truncating
+// them to whole numbers.
+func FormatResolution(w, h int, hz float64, opts ResolutionOptions) string {
+	sep := opts.DecimalSeparator
+	if sep == "" {
+		sep = "."
+	}
+
+	var b strings.Builder
+	b.WriteString(itoa(w))
+	b.WriteString("x")
+	b.WriteString(itoa(h))
+	b.WriteString(" @ ")
+	b.WriteString(formatHz(hz, sep))
+	b.WriteString("Hz")
+
+	if opts.ShowAspectRatio {
+		if ratio := aspectRatioLabel(w, h); ratio != "" {
+			b.WriteString(" (" + ratio + ")")
+		}
 	}
-	return string(rune(mib)) + " MiB"
+
+	return b.String()
+}
+
+// formatHz renders hz with up to three significant fractional digits,
+// trimming trailing zeros, e.g. 59.940001 -> "59.94", 60.0 -> "60".
+func formatHz(hz float64, decimalSeparator string) string {
+	rounded := int(hz*1000 + 0.5)
+	whole := rounded / 1000
+	frac := rounded % 1000
+
+	if frac == 0 {
+		return itoa(whole)
+	}
+
+	fracStr := fmt.Sprintf("%03d", frac)
+	fracStr = strings.TrimRight(fracStr, "0")
+
+	return itoa(whole) + decimalSeparator + fracStr
+}
+
+// aspectRatioLabel reduces w:h by their GCD and returns the canonical
+// marketing name for common ratios, falling back to the reduced "w:h".
+func aspectRatioLabel(w, h int) string {
+	if w <= 0 || h <= 0 {
+		return ""
+	}
+
+	divisor := gcd(w, h)
+	rw, rh := w/divisor, h/divisor
+
+	if name, ok := commonAspectRatios[[2]int{rw, rh}]; ok {
+		return name
+	}
+	return itoa(rw) + ":" + itoa(rh)
 }
 
-func formatResolution(w, h int, hz float64) string {
-	return strings.Join([]string{
-		itoa(w), "x", itoa(h), " @ ", itoa(int(hz)), "Hz",
-	}, "")
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
 }
 
 func itoa(i int) string {
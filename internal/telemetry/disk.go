@@ -0,0 +1,175 @@
+package telemetry
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// DiskInfo captures one mounted filesystem's usage at snapshot time.
+// Device is deliberately absent - DiskHash anonymizes it the same way
+// machineHash anonymizes the machine, so a snapshot can't be used to
+// fingerprint a specific drive.
+type DiskInfo struct {
+	DiskHash    string  `json:"disk_hash"`
+	FSType      string  `json:"fs_type"`
+	Mountpoint  string  `json:"mountpoint"`
+	TotalBytes  uint64  `json:"total_bytes"`
+	UsedBytes   uint64  `json:"used_bytes"`
+	FreeBytes   uint64  `json:"free_bytes"`
+	PercentUsed float64 `json:"percent_used"`
+}
+
+// pseudoFSTypes are mounts that don't represent real storage and would just
+// be noise in a disk inventory (loop devices are filtered separately, by
+// device path rather than fstype).
+var pseudoFSTypes = map[string]bool{
+	"devfs":       true,
+	"devtmpfs":    true,
+	"devpts":      true,
+	"proc":        true,
+	"sysfs":       true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"tmpfs":       true,
+	"overlay":     true,
+	"squashfs":    true,
+	"autofs":      true,
+	"mqueue":      true,
+	"debugfs":     true,
+	"tracefs":     true,
+	"pstore":      true,
+	"securityfs":  true,
+	"configfs":    true,
+	"binfmt_misc": true,
+}
+
+type mountEntry struct {
+	device     string
+	mountpoint string
+	fstype     string
+}
+
+// collectDisks reads /proc/mounts and statfs's each real mount to build a
+// disk inventory, skipping loop devices and pseudo filesystems the way the
+// RMM Linux agent does.
+func collectDisks() []DiskInfo {
+	mounts, err := parseProcMounts("/proc/mounts")
+	if err != nil {
+		return nil
+	}
+
+	var disks []DiskInfo
+	for _, m := range mounts {
+		if strings.HasPrefix(m.device, "/dev/loop") || pseudoFSTypes[m.fstype] {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(m.mountpoint, &stat); err != nil {
+			continue
+		}
+
+		blockSize := uint64(stat.Bsize)
+		total := stat.Blocks * blockSize
+		free := stat.Bfree * blockSize
+		if total == 0 {
+			continue
+		}
+		used := total - free
+
+		disks = append(disks, DiskInfo{
+			DiskHash:    hashDiskDevice(m.device),
+			FSType:      m.fstype,
+			Mountpoint:  m.mountpoint,
+			TotalBytes:  total,
+			UsedBytes:   used,
+			FreeBytes:   free,
+			PercentUsed: float64(used) / float64(total) * 100,
+		})
+	}
+
+	return disks
+}
+
+// hashDiskDevice anonymizes a device path the same way generateMachineHash
+// anonymizes the machine ID, so a disk inventory can't be correlated back to
+// a specific physical drive.
+func hashDiskDevice(device string) string {
+	combined := "wizado-telemetry-v1:" + device
+	hash := sha256.Sum256([]byte(combined))
+	return hex.EncodeToString(hash[:8])
+}
+
+// parseProcMounts parses /proc/mounts (fstab format: device mountpoint
+// fstype options dump pass), unescaping the octal escapes the kernel uses
+// for spaces and other special characters in paths.
+func parseProcMounts(path string) ([]mountEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []mountEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		entries = append(entries, mountEntry{
+			device:     unescapeMountField(fields[0]),
+			mountpoint: unescapeMountField(fields[1]),
+			fstype:     fields[2],
+		})
+	}
+	return entries, scanner.Err()
+}
+
+// unescapeMountField decodes the \NNN octal escapes /proc/mounts uses for
+// spaces, tabs, newlines, and backslashes in device/mountpoint paths.
+func unescapeMountField(field string) string {
+	if !strings.Contains(field, "\\") {
+		return field
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if n, err := strconv.ParseInt(field[i+1:i+4], 8, 32); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String()
+}
+
+// rebootMarkers are checked in order; the first one present determines
+// RebootReason. The pacman hook marker is Arch/omarchy-specific, alongside
+// the Debian-style paths other tooling may also leave behind.
+var rebootMarkers = []struct {
+	path   string
+	reason string
+}{
+	{"/var/run/reboot-required", "reboot-required marker present"},
+	{"/run/reboot-required", "reboot-required marker present"},
+	{"/var/lib/pacman/needs-reboot", "pending pacman kernel/firmware update"},
+}
+
+// detectRebootRequired probes the known reboot-pending markers.
+func detectRebootRequired() (required bool, reason string) {
+	for _, marker := range rebootMarkers {
+		if _, err := os.Stat(marker.path); err == nil {
+			return true, marker.reason
+		}
+	}
+	return false, ""
+}
@@ -0,0 +1,118 @@
+package telemetry
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// gpuSeriesCapacity bounds the ring buffer so a long session doesn't grow unbounded
+const gpuSeriesCapacity = 1800 // 1 hour at a 2s sample interval
+
+// GPUSample is a single point-in-time reading of GPU load and thermals
+type GPUSample struct {
+	Timestamp  time.Time `json:"timestamp"`
+	UtilGPU    float64   `json:"util_gpu_pct"`
+	UtilMemory float64   `json:"util_memory_pct"`
+	TempC      float64   `json:"temp_c"`
+	PowerW     float64   `json:"power_w"`
+	ClockGrMHz float64   `json:"clock_gr_mhz"`
+	ClockMemMHz float64  `json:"clock_mem_mhz"`
+	MemUsedMiB float64   `json:"mem_used_mib"`
+}
+
+// GPUReduction summarizes a GPUTimeSeries with min/max/avg/p95 for each metric
+type GPUReduction struct {
+	Samples int            `json:"samples"`
+	UtilGPU MetricSummary  `json:"util_gpu_pct"`
+	TempC   MetricSummary  `json:"temp_c"`
+	PowerW  MetricSummary  `json:"power_w"`
+}
+
+// MetricSummary holds the reduced statistics for one metric across a series
+type MetricSummary struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+	Avg float64 `json:"avg"`
+	P95 float64 `json:"p95"`
+}
+
+// GPUTimeSeries is a fixed-capacity ring buffer of GPU samples collected during a session
+type GPUTimeSeries struct {
+	mu     sync.Mutex
+	cap    int
+	start  int
+	count  int
+	data   []GPUSample
+}
+
+// NewGPUTimeSeries creates a ring buffer capped at gpuSeriesCapacity samples
+func NewGPUTimeSeries() *GPUTimeSeries {
+	return &GPUTimeSeries{
+		cap:  gpuSeriesCapacity,
+		data: make([]GPUSample, gpuSeriesCapacity),
+	}
+}
+
+// Add appends a sample, evicting the oldest one once the buffer is full
+func (s *GPUTimeSeries) Add(sample GPUSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := (s.start + s.count) % s.cap
+	s.data[idx] = sample
+
+	if s.count < s.cap {
+		s.count++
+	} else {
+		s.start = (s.start + 1) % s.cap
+	}
+}
+
+// Samples returns a copy of the buffered samples in chronological order
+func (s *GPUTimeSeries) Samples() []GPUSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]GPUSample, s.count)
+	for i := 0; i < s.count; i++ {
+		out[i] = s.data[(s.start+i)%s.cap]
+	}
+	return out
+}
+
+// Reduce computes min/max/avg/p95 across the buffered samples
+func (s *GPUTimeSeries) Reduce() GPUReduction {
+	samples := s.Samples()
+
+	return GPUReduction{
+		Samples: len(samples),
+		UtilGPU: reduceMetric(samples, func(s GPUSample) float64 { return s.UtilGPU }),
+		TempC:   reduceMetric(samples, func(s GPUSample) float64 { return s.TempC }),
+		PowerW:  reduceMetric(samples, func(s GPUSample) float64 { return s.PowerW }),
+	}
+}
+
+func reduceMetric(samples []GPUSample, get func(GPUSample) float64) MetricSummary {
+	if len(samples) == 0 {
+		return MetricSummary{}
+	}
+
+	values := make([]float64, len(samples))
+	sum := 0.0
+	for i, s := range samples {
+		v := get(s)
+		values[i] = v
+		sum += v
+	}
+	sort.Float64s(values)
+
+	p95Idx := int(float64(len(values)-1) * 0.95)
+
+	return MetricSummary{
+		Min: values[0],
+		Max: values[len(values)-1],
+		Avg: sum / float64(len(values)),
+		P95: values[p95Idx],
+	}
+}
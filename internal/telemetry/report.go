@@ -0,0 +1,427 @@
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Report is a local-only rollup of recorded telemetry events over a period.
+// Nothing it contains ever leaves the machine on its own - it exists so the
+// user gets some benefit from "we collect but don't use it" data before any
+// remote upload (see Uploader) is even configured.
+type Report struct {
+	Period      string    `json:"period"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	TotalSessions     int     `json:"total_sessions"`
+	MeanDurationSec   float64 `json:"mean_duration_seconds"`
+	MedianDurationSec float64 `json:"median_duration_seconds"`
+	P95DurationSec    float64 `json:"p95_duration_seconds"`
+
+	CrashesByExitCode map[int]ExitCodeStat `json:"crashes_by_exit_code,omitempty"`
+
+	ResolutionDistribution map[string]int `json:"resolution_distribution,omitempty"`
+	FSRDistribution        map[string]int `json:"fsr_distribution,omitempty"`
+	FrameLimitDistribution map[int]int    `json:"frame_limit_distribution,omitempty"`
+
+	ErrorsByComponent map[string]int `json:"errors_by_component,omitempty"`
+}
+
+// ExitCodeStat is how often one exit code occurred and what share of total
+// sessions that represents.
+type ExitCodeStat struct {
+	Count int     `json:"count"`
+	Rate  float64 `json:"rate"` // count / TotalSessions
+}
+
+// reportRecord is the lightweight, per-event extract GenerateReport keeps
+// around in reports/index.json - just enough fields to aggregate a Report
+// for any period, without re-reading the full event JSONL files every time.
+type reportRecord struct {
+	Timestamp time.Time `json:"ts"`
+	Kind      string    `json:"kind"` // "session_exit" or "error"
+
+	DurationSec float64 `json:"duration_sec,omitempty"`
+	ExitCode    int     `json:"exit_code,omitempty"`
+	Resolution  string  `json:"resolution,omitempty"`
+	FSR         string  `json:"fsr,omitempty"`
+	FrameLimit  int     `json:"frame_limit,omitempty"`
+
+	Component string `json:"component,omitempty"`
+}
+
+// reportIndex is persisted as reports/index.json: the byte offset already
+// processed in each daily event file, plus every record extracted so far,
+// so GenerateReport only has to scan newly-appended lines.
+type reportIndex struct {
+	Offsets map[string]int64 `json:"offsets"`
+	Records []reportRecord   `json:"records"`
+}
+
+func (s *Store) reportsDir() string {
+	return filepath.Join(s.dataDir, "reports")
+}
+
+func (s *Store) reportIndexPath() string {
+	return filepath.Join(s.reportsDir(), "index.json")
+}
+
+func (s *Store) loadReportIndex() reportIndex {
+	data, err := os.ReadFile(s.reportIndexPath())
+	if err != nil {
+		return reportIndex{Offsets: map[string]int64{}}
+	}
+	var idx reportIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return reportIndex{Offsets: map[string]int64{}}
+	}
+	if idx.Offsets == nil {
+		idx.Offsets = map[string]int64{}
+	}
+	return idx
+}
+
+func (s *Store) saveReportIndex(idx reportIndex) error {
+	if err := os.MkdirAll(s.reportsDir(), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.reportIndexPath(), data, 0600)
+}
+
+// ParsePeriod parses a "--since" duration like "7d", "24h", or "30m". It
+// extends time.ParseDuration with a "d" (day) suffix, which that function
+// doesn't support.
+func ParsePeriod(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid period %q: %w", s, err)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// GenerateReport rolls up session and error events from the last `period`
+// into a Report. It scans each daily events/*.jsonl file line-by-line with
+// bufio.Scanner (so multi-MB files don't have to be loaded whole), resuming
+// from the byte offset recorded for that file in reports/index.json so a
+// repeated invocation only reads lines appended since the last call.
+func (s *Store) GenerateReport(period time.Duration) (*Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.loadReportIndex()
+
+	eventsDir := filepath.Join(s.dataDir, "events")
+	entries, err := os.ReadDir(eventsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".jsonl" {
+			continue
+		}
+		if err := s.scanEventFile(filepath.Join(eventsDir, e.Name()), e.Name(), &idx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.saveReportIndex(idx); err != nil {
+		return nil, err
+	}
+
+	return buildReport(idx.Records, period), nil
+}
+
+// scanEventFile appends every record since idx.Offsets[name] to idx.Records
+// and advances the offset.
+func (s *Store) scanEventFile(path, name string, idx *reportIndex) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offset := idx.Offsets[name]
+	if stat, err := f.Stat(); err == nil && offset > stat.Size() {
+		offset = 0 // file was truncated/rotated out from under us
+	}
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if record, ok := parseReportRecord(scanner.Bytes()); ok {
+			idx.Records = append(idx.Records, record)
+		}
+		offset += int64(len(scanner.Bytes())) + 1 // +1 for the newline Scanner strips
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	idx.Offsets[name] = offset
+	return nil
+}
+
+// parseReportRecord extracts a reportRecord from one raw Event line, if it's
+// a kind the report cares about.
+func parseReportRecord(line []byte) (reportRecord, bool) {
+	var event Event
+	if err := json.Unmarshal(line, &event); err != nil {
+		return reportRecord{}, false
+	}
+
+	switch event.Type {
+	case EventExit:
+		session, ok := event.Data["session"].(map[string]any)
+		if !ok {
+			return reportRecord{}, false
+		}
+		return reportRecord{
+			Timestamp:   event.Timestamp,
+			Kind:        "session_exit",
+			DurationSec: durationSeconds(session["duration_seconds"]),
+			ExitCode:    int(floatField(session["exit_code"])),
+			Resolution:  stringField(session["resolution"]),
+			FSR:         stringField(session["fsr"]),
+			FrameLimit:  int(floatField(session["frame_limit"])),
+		}, true
+
+	case EventError:
+		return reportRecord{
+			Timestamp: event.Timestamp,
+			Kind:      "error",
+			Component: stringField(event.Data["component"]),
+		}, true
+	}
+
+	return reportRecord{}, false
+}
+
+// durationSeconds converts SessionData.Duration's encoded value back to
+// seconds. Despite its "duration_seconds" JSON tag, the field is a plain
+// time.Duration and marshals as nanoseconds.
+func durationSeconds(v any) float64 {
+	return floatField(v) / float64(time.Second)
+}
+
+func floatField(v any) float64 {
+	f, _ := v.(float64)
+	return f
+}
+
+func stringField(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// buildReport aggregates the records falling within the last `period` into
+// a Report.
+func buildReport(records []reportRecord, period time.Duration) *Report {
+	cutoff := time.Now().UTC().Add(-period)
+
+	report := &Report{
+		Period:                  period.String(),
+		GeneratedAt:             time.Now().UTC(),
+		CrashesByExitCode:       map[int]ExitCodeStat{},
+		ResolutionDistribution:  map[string]int{},
+		FSRDistribution:         map[string]int{},
+		FrameLimitDistribution:  map[int]int{},
+		ErrorsByComponent:       map[string]int{},
+	}
+
+	var durations []float64
+	exitCodeCounts := map[int]int{}
+
+	for _, r := range records {
+		if r.Timestamp.Before(cutoff) {
+			continue
+		}
+
+		switch r.Kind {
+		case "session_exit":
+			report.TotalSessions++
+			durations = append(durations, r.DurationSec)
+			exitCodeCounts[r.ExitCode]++
+			if r.Resolution != "" {
+				report.ResolutionDistribution[r.Resolution]++
+			}
+			if r.FSR != "" {
+				report.FSRDistribution[r.FSR]++
+			}
+			if r.FrameLimit != 0 {
+				report.FrameLimitDistribution[r.FrameLimit]++
+			}
+		case "error":
+			if r.Component != "" {
+				report.ErrorsByComponent[r.Component]++
+			}
+		}
+	}
+
+	report.MeanDurationSec = mean(durations)
+	report.MedianDurationSec = percentile(durations, 50)
+	report.P95DurationSec = percentile(durations, 95)
+
+	for code, count := range exitCodeCounts {
+		rate := 0.0
+		if report.TotalSessions > 0 {
+			rate = float64(count) / float64(report.TotalSessions)
+		}
+		report.CrashesByExitCode[code] = ExitCodeStat{Count: count, Rate: rate}
+	}
+
+	return report
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// percentile returns the p-th percentile (0-100) of values using nearest-rank.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// ToJSON serializes the report to JSON
+func (r *Report) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Summary returns a human-readable text rendering of the report
+func (r *Report) Summary() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Telemetry Report (last %s)\n", r.Period)
+	b.WriteString("══════════════════════════\n\n")
+
+	fmt.Fprintf(&b, "Sessions: %d\n", r.TotalSessions)
+	fmt.Fprintf(&b, "Duration: mean %.0fs, median %.0fs, p95 %.0fs\n\n",
+		r.MeanDurationSec, r.MedianDurationSec, r.P95DurationSec)
+
+	b.WriteString("Crashes by exit code:\n")
+	for _, code := range sortedExitCodes(r.CrashesByExitCode) {
+		stat := r.CrashesByExitCode[code]
+		fmt.Fprintf(&b, "  %d: %d (%.1f%%)\n", code, stat.Count, stat.Rate*100)
+	}
+
+	b.WriteString("\nResolution distribution:\n")
+	for _, res := range sortedStringKeys(r.ResolutionDistribution) {
+		fmt.Fprintf(&b, "  %s: %d\n", res, r.ResolutionDistribution[res])
+	}
+
+	b.WriteString("\nFSR distribution:\n")
+	for _, fsr := range sortedStringKeys(r.FSRDistribution) {
+		fmt.Fprintf(&b, "  %s: %d\n", fsr, r.FSRDistribution[fsr])
+	}
+
+	b.WriteString("\nFrame limit distribution:\n")
+	for _, fl := range sortedFrameLimits(r.FrameLimitDistribution) {
+		fmt.Fprintf(&b, "  %d: %d\n", fl, r.FrameLimitDistribution[fl])
+	}
+
+	b.WriteString("\nErrors by component:\n")
+	for _, comp := range sortedStringKeys(r.ErrorsByComponent) {
+		fmt.Fprintf(&b, "  %s: %d\n", comp, r.ErrorsByComponent[comp])
+	}
+
+	return b.String()
+}
+
+// ToCSV renders the report as a flat "metric,value" CSV - one row per
+// distribution entry - so it's easy to pipe into a spreadsheet.
+func (r *Report) ToCSV() string {
+	var b strings.Builder
+	b.WriteString("metric,value\n")
+	fmt.Fprintf(&b, "total_sessions,%d\n", r.TotalSessions)
+	fmt.Fprintf(&b, "mean_duration_seconds,%.1f\n", r.MeanDurationSec)
+	fmt.Fprintf(&b, "median_duration_seconds,%.1f\n", r.MedianDurationSec)
+	fmt.Fprintf(&b, "p95_duration_seconds,%.1f\n", r.P95DurationSec)
+
+	for _, code := range sortedExitCodes(r.CrashesByExitCode) {
+		stat := r.CrashesByExitCode[code]
+		fmt.Fprintf(&b, "exit_code_%d_count,%d\n", code, stat.Count)
+		fmt.Fprintf(&b, "exit_code_%d_rate,%.3f\n", code, stat.Rate)
+	}
+	for _, res := range sortedStringKeys(r.ResolutionDistribution) {
+		fmt.Fprintf(&b, "resolution_%s,%d\n", res, r.ResolutionDistribution[res])
+	}
+	for _, fsr := range sortedStringKeys(r.FSRDistribution) {
+		fmt.Fprintf(&b, "fsr_%s,%d\n", fsr, r.FSRDistribution[fsr])
+	}
+	for _, fl := range sortedFrameLimits(r.FrameLimitDistribution) {
+		fmt.Fprintf(&b, "frame_limit_%d,%d\n", fl, r.FrameLimitDistribution[fl])
+	}
+	for _, comp := range sortedStringKeys(r.ErrorsByComponent) {
+		fmt.Fprintf(&b, "errors_%s,%d\n", comp, r.ErrorsByComponent[comp])
+	}
+
+	return b.String()
+}
+
+func sortedStringKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedExitCodes(m map[int]ExitCodeStat) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func sortedFrameLimits(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
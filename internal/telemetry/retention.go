@@ -0,0 +1,293 @@
+package telemetry
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy bounds how much telemetry data Store.Maintain keeps on
+// disk. A zero value disables the corresponding check (MaxAgeDays == 0
+// means no age cap, MaxTotalBytes == 0 means no size cap).
+type RetentionPolicy struct {
+	MaxAgeDays        int   // delete event files older than this, oldest first
+	MaxTotalBytes     int64 // delete oldest event files until the events dir is under this
+	CompressAfterDays int   // gzip event files older than this that aren't already compressed
+}
+
+// DefaultRetentionPolicy matches what a typical desktop install can afford:
+// a year of history, compressed after a week, capped at 100MiB total.
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		MaxAgeDays:        365,
+		MaxTotalBytes:     100 * 1024 * 1024,
+		CompressAfterDays: 7,
+	}
+}
+
+// manifest records a sha256 per event file, so Store.VerifyIntegrity can
+// detect tampering or truncation of files Maintain has already processed.
+type manifest struct {
+	Files map[string]manifestEntry `json:"files"`
+}
+
+type manifestEntry struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+func (s *Store) manifestPath() string {
+	return filepath.Join(s.dataDir, "events", "manifest.json")
+}
+
+func (s *Store) loadManifest() manifest {
+	data, err := os.ReadFile(s.manifestPath())
+	if err != nil {
+		return manifest{Files: map[string]manifestEntry{}}
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return manifest{Files: map[string]manifestEntry{}}
+	}
+	if m.Files == nil {
+		m.Files = map[string]manifestEntry{}
+	}
+	return m
+}
+
+func (s *Store) saveManifest(m manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.manifestPath(), data, 0600)
+}
+
+// eventFileDate extracts the YYYY-MM-DD this event file covers from its
+// name, whether or not it's already gzipped.
+func eventFileDate(name string) (time.Time, bool) {
+	base := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".jsonl")
+	t, err := time.Parse("2006-01-02", base)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Maintain enforces the Store's RetentionPolicy against events/*.jsonl[.gz]:
+// it compresses files older than CompressAfterDays, deletes files beyond
+// MaxAgeDays or MaxTotalBytes (oldest first), and refreshes manifest.json
+// with a sha256 of everything that remains.
+func (s *Store) Maintain(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	eventsDir := filepath.Join(s.dataDir, "events")
+	entries, err := os.ReadDir(eventsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.compressOldEvents(ctx, eventsDir, entries); err != nil {
+		return err
+	}
+
+	// Re-read: compression may have renamed files.
+	entries, err = os.ReadDir(eventsDir)
+	if err != nil {
+		return err
+	}
+
+	if err := s.enforceRetention(ctx, eventsDir, entries); err != nil {
+		return err
+	}
+
+	entries, err = os.ReadDir(eventsDir)
+	if err != nil {
+		return err
+	}
+	return s.refreshManifest(eventsDir, entries)
+}
+
+func (s *Store) compressOldEvents(ctx context.Context, eventsDir string, entries []os.DirEntry) error {
+	if s.retention.CompressAfterDays <= 0 {
+		return nil
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -s.retention.CompressAfterDays)
+
+	for _, e := range entries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if filepath.Ext(e.Name()) != ".jsonl" {
+			continue
+		}
+		date, ok := eventFileDate(e.Name())
+		if !ok || !date.Before(cutoff) {
+			continue
+		}
+		if err := gzipFile(filepath.Join(eventsDir, e.Name())); err != nil {
+			return fmt.Errorf("compressing %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
+
+// gzipFile replaces path with path+".gz" containing the gzipped contents,
+// removing the original only once the compressed copy is fully written.
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dstPath := path + ".gz"
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		dst.Close()
+		os.Remove(dstPath)
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		dst.Close()
+		os.Remove(dstPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dstPath)
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// enforceRetention deletes event files oldest-first once MaxAgeDays or
+// MaxTotalBytes is exceeded. manifest.json itself is never counted or
+// deleted here.
+func (s *Store) enforceRetention(ctx context.Context, eventsDir string, entries []os.DirEntry) error {
+	type fileInfo struct {
+		name string
+		date time.Time
+		size int64
+	}
+
+	var files []fileInfo
+	var total int64
+	for _, e := range entries {
+		if e.Name() == "manifest.json" {
+			continue
+		}
+		date, ok := eventFileDate(e.Name())
+		if !ok {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{name: e.Name(), date: date, size: info.Size()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].date.Before(files[j].date) })
+
+	ageCutoff := time.Time{}
+	if s.retention.MaxAgeDays > 0 {
+		ageCutoff = time.Now().UTC().AddDate(0, 0, -s.retention.MaxAgeDays)
+	}
+
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		overAge := s.retention.MaxAgeDays > 0 && f.date.Before(ageCutoff)
+		overSize := s.retention.MaxTotalBytes > 0 && total > s.retention.MaxTotalBytes
+		if !overAge && !overSize {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(eventsDir, f.name)); err != nil {
+			return err
+		}
+		total -= f.size
+	}
+
+	return nil
+}
+
+func (s *Store) refreshManifest(eventsDir string, entries []os.DirEntry) error {
+	m := manifest{Files: map[string]manifestEntry{}}
+	for _, e := range entries {
+		if e.Name() == "manifest.json" || e.IsDir() {
+			continue
+		}
+		sum, size, err := sha256File(filepath.Join(eventsDir, e.Name()))
+		if err != nil {
+			return err
+		}
+		m.Files[e.Name()] = manifestEntry{SHA256: sum, Size: size}
+	}
+	return s.saveManifest(m)
+}
+
+func sha256File(path string) (sum string, size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// VerifyIntegrity compares every event file against its recorded manifest
+// entry and reports the names of any that are missing, truncated, or whose
+// contents no longer match the stored hash. Files with no manifest entry
+// (never yet covered by a Maintain run) are not reported as mismatches.
+func (s *Store) VerifyIntegrity() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.loadManifest()
+	eventsDir := filepath.Join(s.dataDir, "events")
+
+	var mismatches []string
+	for name, entry := range m.Files {
+		sum, size, err := sha256File(filepath.Join(eventsDir, name))
+		if err != nil {
+			mismatches = append(mismatches, name)
+			continue
+		}
+		if sum != entry.SHA256 || size != entry.Size {
+			mismatches = append(mismatches, name)
+		}
+	}
+
+	sort.Strings(mismatches)
+	return mismatches, nil
+}
@@ -4,12 +4,17 @@
 package telemetry
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/wattfource/wizado/internal/sysinfo"
@@ -51,6 +56,20 @@ type SessionData struct {
 	MangoHUD    bool   `json:"mangohud"`
 	GameMode    bool   `json:"gamemode"`
 	SteamUI     string `json:"steam_ui"`
+
+	// GPU load/thermals sampled over the session, if a sampler was attached
+	GPU         *GPUReduction `json:"gpu,omitempty"`
+
+	// Frame pacing stats parsed from the MangoHUD CSV log, if logging was enabled
+	FPS         *FPSSummary `json:"fps,omitempty"`
+}
+
+// FPSSummary captures average and low-percentile FPS for a gaming session
+type FPSSummary struct {
+	Frames   int     `json:"frames"`
+	AvgFPS   float64 `json:"avg_fps"`
+	Low1FPS  float64 `json:"low_1pct_fps"`
+	Low01FPS float64 `json:"low_0_1pct_fps"`
 }
 
 // SystemSnapshot captures system info at a point in time
@@ -86,6 +105,12 @@ type SystemSnapshot struct {
 	HasGamescope bool `json:"has_gamescope"`
 	HasGamemode  bool `json:"has_gamemode"`
 	HasMangohud  bool `json:"has_mangohud"`
+
+	// Storage and pending system state - useful for correlating launch/exit
+	// events with a near-full Steam library or a stale kernel
+	Disks          []DiskInfo `json:"disks,omitempty"`
+	RebootRequired bool       `json:"reboot_required"`
+	RebootReason   string     `json:"reboot_reason,omitempty"`
 }
 
 // Store handles telemetry storage
@@ -95,6 +120,7 @@ type Store struct {
 	enabled  bool
 	version  string
 	machineHash string
+	retention   RetentionPolicy
 }
 
 // Config for telemetry
@@ -102,6 +128,17 @@ type Config struct {
 	Enabled bool   // Whether telemetry collection is enabled
 	DataDir string // Directory to store telemetry data
 	Version string // Wizado version
+
+	// Phase 2: remote reporting. RemoteEnabled must be explicitly set - it
+	// never defaults on - and is independent of the live consent recorded in
+	// consent.json, which Uploader.Enable/Disable update at runtime.
+	RemoteEnabled      bool
+	RemoteEndpoint     string
+	UploadInterval     time.Duration // how often the Uploader's background loop runs
+	InsecureSkipVerify bool          // disable TLS verification, for testing against a dev endpoint
+
+	// Retention governs how Store.Maintain compacts and prunes events on disk.
+	Retention RetentionPolicy
 }
 
 // DefaultConfig returns default telemetry configuration
@@ -111,6 +148,12 @@ func DefaultConfig() Config {
 		Enabled: true, // Collect locally by default
 		DataDir: filepath.Join(home, ".local", "share", "wizado", "telemetry"),
 		Version: "dev",
+
+		RemoteEnabled:  false,
+		RemoteEndpoint: "https://telemetry.wizado.app",
+		UploadInterval: 1 * time.Hour,
+
+		Retention: DefaultRetentionPolicy(),
 	}
 }
 
@@ -139,9 +182,10 @@ func Default() *Store {
 // NewStore creates a new telemetry store
 func NewStore(cfg Config) (*Store, error) {
 	s := &Store{
-		dataDir: cfg.DataDir,
-		enabled: cfg.Enabled,
-		version: cfg.Version,
+		dataDir:   cfg.DataDir,
+		enabled:   cfg.Enabled,
+		version:   cfg.Version,
+		retention: cfg.Retention,
 	}
 	
 	if cfg.Enabled {
@@ -233,7 +277,7 @@ func (s *Store) RecordSystemSnapshot(sysInfo *sysinfo.SystemInfo) error {
 		RAMGiB:   sysInfo.Memory.TotalMiB / 1024,
 		
 		// GPU
-		GPUDriver: sysInfo.GPU.DriverVersion,
+		GPUDriver: gpuDriverVersion(sysInfo.GPU),
 		
 		// Display
 		ResolutionW: sysInfo.Display.Primary.Width,
@@ -246,7 +290,7 @@ func (s *Store) RecordSystemSnapshot(sysInfo *sysinfo.SystemInfo) error {
 		HasController: sysInfo.Input.HasController,
 		
 		// Network
-		ConnectionType: sysInfo.Network.ConnectionType,
+		ConnectionType: connectionType(sysInfo.Network),
 		
 		// Software
 		OSName:      sysInfo.OS.Name,
@@ -256,14 +300,18 @@ func (s *Store) RecordSystemSnapshot(sysInfo *sysinfo.SystemInfo) error {
 		HasGamescope: sysInfo.Dependencies.Gamescope.Installed,
 		HasGamemode:  sysInfo.Dependencies.GameMode.Installed,
 		HasMangohud:  sysInfo.Dependencies.MangoHUD.Installed,
+
+		Disks: collectDisks(),
 	}
+
+	snapshot.RebootRequired, snapshot.RebootReason = detectRebootRequired()
 	
 	// Determine GPU type
-	if sysInfo.GPU.HasNVIDIA {
+	if sysInfo.GPU.HasNVIDIA() {
 		snapshot.GPUType = "nvidia"
-	} else if sysInfo.GPU.HasAMD {
+	} else if sysInfo.GPU.HasAMD() {
 		snapshot.GPUType = "amd"
-	} else if sysInfo.GPU.HasIntel {
+	} else if sysInfo.GPU.HasIntel() {
 		snapshot.GPUType = "intel"
 	}
 	
@@ -274,6 +322,24 @@ func (s *Store) RecordSystemSnapshot(sysInfo *sysinfo.SystemInfo) error {
 	return s.writeSnapshot(snapshot)
 }
 
+// gpuDriverVersion returns the NVIDIA driver version for the snapshot, the
+// only vendor we currently have one for.
+func gpuDriverVersion(gpu sysinfo.GPUInfo) string {
+	if nvidia := gpu.ByVendor("nvidia"); nvidia != nil {
+		return nvidia.DriverVersion
+	}
+	return ""
+}
+
+// connectionType returns the primary interface's type ("ethernet"/"wifi"),
+// or "" if none was detected.
+func connectionType(network sysinfo.NetworkInfo) string {
+	if primary := network.Primary(); primary != nil {
+		return primary.Type
+	}
+	return ""
+}
+
 func (s *Store) writeEvent(event Event) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -319,11 +385,22 @@ func (s *Store) writeSnapshot(snapshot SystemSnapshot) error {
 	return os.WriteFile(filename, data, 0600)
 }
 
+// eventIDCounter gives every ID generated by this process a distinct,
+// increasing suffix.
+var eventIDCounter uint64
+
+// generateEventID returns a 16-random-byte hex ID suffixed with a
+// monotonic per-process counter. The random prefix makes IDs unguessable;
+// only the counter suffix reflects the order IDs were generated in.
 func generateEventID() string {
-	// Simple timestamp-based ID
-	now := time.Now().UnixNano()
-	hash := sha256.Sum256([]byte(string(rune(now))))
-	return hex.EncodeToString(hash[:8])
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand is not expected to fail, but fall back to a
+		// timestamp rather than an all-zero ID if it ever does.
+		binary.BigEndian.PutUint64(buf, uint64(time.Now().UnixNano()))
+	}
+	counter := atomic.AddUint64(&eventIDCounter, 1)
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(buf), counter)
 }
 
 // GetStats returns summary statistics from collected telemetry
@@ -340,10 +417,15 @@ func (s *Store) GetStats() (map[string]any, error) {
 	// Count events
 	eventsDir := filepath.Join(s.dataDir, "events")
 	eventCount := 0
-	
+	compressedFiles := 0
+	uncompressedFiles := 0
+	var oldestDate string
+
 	files, _ := os.ReadDir(eventsDir)
 	for _, f := range files {
-		if filepath.Ext(f.Name()) == ".jsonl" {
+		switch {
+		case strings.HasSuffix(f.Name(), ".jsonl"):
+			uncompressedFiles++
 			// Count lines in file
 			data, err := os.ReadFile(filepath.Join(eventsDir, f.Name()))
 			if err == nil {
@@ -353,11 +435,25 @@ func (s *Store) GetStats() (map[string]any, error) {
 					}
 				}
 			}
+		case strings.HasSuffix(f.Name(), ".jsonl.gz"):
+			compressedFiles++
+		default:
+			continue
+		}
+
+		if date, ok := eventFileDate(f.Name()); ok {
+			dateStr := date.Format("2006-01-02")
+			if oldestDate == "" || dateStr < oldestDate {
+				oldestDate = dateStr
+			}
 		}
 	}
-	
+
 	stats["event_count"] = eventCount
-	stats["event_files"] = len(files)
+	stats["event_files"] = uncompressedFiles + compressedFiles
+	stats["uncompressed_files"] = uncompressedFiles
+	stats["compressed_files"] = compressedFiles
+	stats["oldest_retained_date"] = oldestDate
 	
 	// Check for snapshot
 	snapshotPath := filepath.Join(s.dataDir, "snapshots", "system.json")
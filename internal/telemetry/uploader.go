@@ -0,0 +1,426 @@
+package telemetry
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// pingTimeout bounds the liveness probe Uploader does before each batch.
+const pingTimeout = 5 * time.Second
+
+// uploadTimeout bounds a single batch POST.
+const uploadTimeout = 30 * time.Second
+
+// maxBackoff caps the exponential backoff applied after consecutive upload
+// failures, so a long outage doesn't push the next attempt out for days.
+const maxBackoff = 30 * time.Minute
+
+// ConsentRecord is the on-disk proof that the user opted into remote
+// reporting: when, against which wizado version, and for which endpoint
+// (hashed, not stored verbatim, since the record itself isn't secret but
+// there's no reason to spell the URL out in a file that might get attached
+// to a support ticket). Enable/Disable are the only writers.
+type ConsentRecord struct {
+	Enabled      bool      `json:"enabled"`
+	Timestamp    time.Time `json:"timestamp"`
+	Version      string    `json:"version"`
+	EndpointHash string    `json:"endpointHash"`
+}
+
+// checkpoints maps a data file's path (relative to the telemetry data dir)
+// to the byte offset already uploaded from it, so a crash mid-upload resumes
+// instead of re-sending or dropping data.
+type checkpoints map[string]int64
+
+// Uploader batches the Store's on-disk events and system snapshot into
+// gzipped NDJSON and POSTs them to a remote endpoint. It is modeled on a
+// simple agent check-in loop: a liveness ping before each batch, a fixed
+// interval between cycles, and exponential backoff after failures.
+type Uploader struct {
+	store    *Store
+	endpoint string
+	interval time.Duration
+	client   *http.Client
+
+	remoteEnabled bool
+
+	mu      sync.Mutex
+	backoff time.Duration
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewUploader creates an Uploader for store using cfg's remote settings. It
+// does not start the background loop - call Start for that - and does not
+// by itself imply consent; Start refuses to run unless both
+// cfg.RemoteEnabled and a live "enabled" consent record are present.
+func NewUploader(store *Store, cfg Config) *Uploader {
+	transport := &http.Transport{}
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &Uploader{
+		store:    store,
+		endpoint: cfg.RemoteEndpoint,
+		interval: cfg.UploadInterval,
+		client:   &http.Client{Transport: transport},
+
+		remoteEnabled: cfg.RemoteEnabled,
+	}
+}
+
+func (u *Uploader) consentPath() string {
+	return filepath.Join(u.store.dataDir, "consent.json")
+}
+
+func (u *Uploader) checkpointsPath() string {
+	return filepath.Join(u.store.dataDir, "upload_checkpoints.json")
+}
+
+// hashEndpoint returns a short, non-reversible fingerprint of the upload
+// endpoint for the consent record.
+func hashEndpoint(endpoint string) string {
+	hash := sha256.Sum256([]byte(endpoint))
+	return hex.EncodeToString(hash[:8])
+}
+
+// Enable records consent to upload telemetry to this Uploader's configured
+// endpoint and allows the background loop to run.
+func (u *Uploader) Enable(version string) error {
+	record := ConsentRecord{
+		Enabled:      true,
+		Timestamp:    time.Now().UTC(),
+		Version:      version,
+		EndpointHash: hashEndpoint(u.endpoint),
+	}
+	if err := u.saveConsent(record); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Disable withdraws consent. The consent record is kept (with Enabled:
+// false) rather than deleted, so there's a durable record of when reporting
+// was turned off.
+func (u *Uploader) Disable() error {
+	record := u.loadConsent()
+	record.Enabled = false
+	record.Timestamp = time.Now().UTC()
+	if err := u.saveConsent(record); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HasConsent reports whether a live "enabled" consent record exists for
+// this endpoint.
+func (u *Uploader) HasConsent() bool {
+	record := u.loadConsent()
+	return record.Enabled && record.EndpointHash == hashEndpoint(u.endpoint)
+}
+
+func (u *Uploader) loadConsent() ConsentRecord {
+	data, err := os.ReadFile(u.consentPath())
+	if err != nil {
+		return ConsentRecord{}
+	}
+	var record ConsentRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return ConsentRecord{}
+	}
+	return record
+}
+
+func (u *Uploader) saveConsent(record ConsentRecord) error {
+	if err := os.MkdirAll(u.store.dataDir, 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(u.consentPath(), data, 0600)
+}
+
+// Start launches the background upload loop. It is a no-op if
+// cfg.RemoteEnabled was false or consent hasn't been recorded via Enable -
+// the caller is expected to check HasConsent (or just call Enable) before
+// relying on this to do anything.
+func (u *Uploader) Start() {
+	if !u.remoteEnabled || !u.HasConsent() {
+		return
+	}
+
+	u.mu.Lock()
+	if u.stopCh != nil {
+		u.mu.Unlock()
+		return // already running
+	}
+	u.stopCh = make(chan struct{})
+	stopCh := u.stopCh
+	u.mu.Unlock()
+
+	u.wg.Add(1)
+	go u.run(stopCh)
+}
+
+// Stop halts the background loop, waiting for any in-flight cycle to finish.
+func (u *Uploader) Stop() {
+	u.mu.Lock()
+	stopCh := u.stopCh
+	u.stopCh = nil
+	u.mu.Unlock()
+
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	u.wg.Wait()
+}
+
+func (u *Uploader) run(stopCh chan struct{}) {
+	defer u.wg.Done()
+
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			u.cycle()
+			u.mu.Lock()
+			next := u.interval + u.backoff
+			u.mu.Unlock()
+			ticker.Reset(next)
+		}
+	}
+}
+
+// cycle runs one upload attempt, adjusting the backoff based on the result.
+func (u *Uploader) cycle() {
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	if err := u.uploadOnce(ctx); err != nil {
+		u.mu.Lock()
+		if u.backoff == 0 {
+			u.backoff = 1 * time.Minute
+		} else {
+			u.backoff *= 2
+			if u.backoff > maxBackoff {
+				u.backoff = maxBackoff
+			}
+		}
+		u.mu.Unlock()
+		return
+	}
+
+	u.mu.Lock()
+	u.backoff = 0
+	u.mu.Unlock()
+}
+
+// Flush runs one upload cycle immediately and waits for it to finish, for
+// graceful shutdown (e.g. on program exit) so the most recent events don't
+// wait for the next ticker interval.
+func (u *Uploader) Flush(ctx context.Context) error {
+	if !u.HasConsent() {
+		return nil
+	}
+	return u.uploadOnce(ctx)
+}
+
+// uploadOnce pings the endpoint, then uploads every pending file (event
+// logs and the system snapshot) that has bytes beyond its checkpoint.
+func (u *Uploader) uploadOnce(ctx context.Context) error {
+	if err := u.ping(ctx); err != nil {
+		return fmt.Errorf("telemetry: endpoint unreachable: %w", err)
+	}
+
+	cps := u.loadCheckpoints()
+
+	files, err := u.pendingFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, relPath := range files {
+		if err := u.uploadFile(ctx, relPath, cps); err != nil {
+			u.saveCheckpoints(cps) // persist whatever progress we made before the failure
+			return err
+		}
+	}
+
+	return u.saveCheckpoints(cps)
+}
+
+// ping does a lightweight liveness check before committing to a batch
+// upload, so a dead endpoint fails fast without reading/gzipping files.
+func (u *Uploader) ping(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(pingCtx, http.MethodGet, u.endpoint+"/ping", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("ping returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// pendingFiles lists the event JSONL files (oldest first) plus the system
+// snapshot, relative to the telemetry data dir.
+func (u *Uploader) pendingFiles() ([]string, error) {
+	var files []string
+
+	eventsDir := filepath.Join(u.store.dataDir, "events")
+	entries, err := os.ReadDir(eventsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var eventFiles []string
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".jsonl" {
+			eventFiles = append(eventFiles, filepath.Join("events", e.Name()))
+		}
+	}
+	sort.Strings(eventFiles)
+	files = append(files, eventFiles...)
+
+	snapshotRel := filepath.Join("snapshots", "system.json")
+	if _, err := os.Stat(filepath.Join(u.store.dataDir, snapshotRel)); err == nil {
+		files = append(files, snapshotRel)
+	}
+
+	return files, nil
+}
+
+// uploadFile sends the bytes of relPath beyond its checkpoint as a gzipped
+// NDJSON batch, advancing the checkpoint on success.
+func (u *Uploader) uploadFile(ctx context.Context, relPath string, cps checkpoints) error {
+	fullPath := filepath.Join(u.store.dataDir, relPath)
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	offset := cps[relPath]
+	if offset >= stat.Size() {
+		return nil // nothing new since last upload
+	}
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	body, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	payload, err := gzipNDJSON(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.endpoint+"/batch", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("X-Wizado-Source", relPath)
+	req.Header.Set("X-Wizado-Machine", u.store.machineHash)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("upload of %s returned status %d", relPath, resp.StatusCode)
+	}
+
+	cps[relPath] = stat.Size()
+	return nil
+}
+
+// gzipNDJSON wraps a file's raw bytes (already newline-delimited JSON for
+// the events files; a single JSON document for the snapshot, which is still
+// valid NDJSON - just one line) in a gzip envelope.
+func gzipNDJSON(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+
+	if _, err := gz.Write(body); err != nil {
+		gz.Close()
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *Uploader) loadCheckpoints() checkpoints {
+	data, err := os.ReadFile(u.checkpointsPath())
+	if err != nil {
+		return checkpoints{}
+	}
+	var cps checkpoints
+	if err := json.Unmarshal(data, &cps); err != nil {
+		return checkpoints{}
+	}
+	return cps
+}
+
+func (u *Uploader) saveCheckpoints(cps checkpoints) error {
+	if err := os.MkdirAll(u.store.dataDir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cps)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(u.checkpointsPath(), data, 0600)
+}
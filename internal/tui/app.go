@@ -5,6 +5,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/wattfource/wizado/internal/config"
+	"github.com/wattfource/wizado/internal/license"
 )
 
 // Screen represents different TUI screens
@@ -15,6 +17,11 @@ const (
 	ScreenLicenseEntry
 	ScreenLicenseStatus
 	ScreenSettings
+	ScreenLogs
+	ScreenReport
+	ScreenUninstall
+	ScreenGameLibrary
+	ScreenSystemInfo
 )
 
 // Colors
@@ -66,11 +73,32 @@ var (
 			MarginTop(1)
 )
 
+// MenuItem is one entry in the main menu. RequiredFeature is a
+// license.LicensePayload.Features key the current license must grant for
+// the item to be selectable; empty means every tier can use it. This lets a
+// future premium entry (e.g. "Cloud Sync") be added without touching
+// selectMenuItem's gating logic.
+type MenuItem struct {
+	Label           string
+	RequiredFeature string
+}
+
+// menuItems is the main menu, in display/selection order.
+var menuItems = []MenuItem{
+	{Label: "Launch Steam"},
+	{Label: "License"},
+	{Label: "Settings"},
+	{Label: "System Info"},
+	{Label: "Logs"},
+	{Label: "Report"},
+	{Label: "Uninstall"},
+	{Label: "Exit"},
+}
+
 // Model represents the TUI state
 type Model struct {
 	screen       Screen
 	cursor       int
-	menuItems    []string
 	width        int
 	height       int
 	
@@ -86,10 +114,42 @@ type Model struct {
 	// License status
 	licenseStatus string
 	licenseEmail  string
-	
+
+	// manager runs the background license checks; licenseResult is the
+	// last Result it reported, cached here so views render from it instead
+	// of calling license.Check() (file read + signature verify, sometimes
+	// a network call) on every frame.
+	manager        *license.Manager
+	licenseResult  license.Result
+	licenseUpdates chan license.Result
+
+	// configWatcher pushes a fresh Config onto configUpdates whenever
+	// ~/.config/wizado/config or its conf.d drop-ins change on disk, so
+	// Settings reflects edits made outside the TUI without needing a
+	// restart. Nil if the watch couldn't be started (e.g. inotify limits).
+	configWatcher  *config.Watcher
+	configUpdates  chan *config.Config
+
 	// Settings
 	settings     *SettingsModel
-	
+
+	// logsModel is created lazily the first time the Logs screen is opened
+	logsModel    *LogsModel
+
+	// uninstallModel is created lazily the first time the Uninstall screen is opened
+	uninstallModel *UninstallModel
+
+	// reportModel is created lazily the first time the Report screen is opened
+	reportModel *ReportModel
+
+	// gameLibrary is created lazily the first time the game library screen
+	// is opened
+	gameLibrary *GameLibraryModel
+
+	// sysInfo is created lazily the first time the System Info screen is
+	// opened
+	sysInfo *SystemInfoModel
+
 	// Should quit
 	quitting     bool
 	
@@ -108,25 +168,84 @@ func NewModel() Model {
 	keyInput.Placeholder = "XXXX-XXXX-XXXX"
 	keyInput.CharLimit = 20
 	keyInput.Width = 20
-	
+
+	manager := license.NewManager()
+	updates := make(chan license.Result, 1)
+	manager.Subscribe(func(r license.Result) {
+		select {
+		case updates <- r:
+		default:
+			// previous update hasn't been consumed yet; the next tick (or
+			// Refresh) will deliver a fresh one
+		}
+	})
+
+	var configWatcher *config.Watcher
+	configUpdates := make(chan *config.Config, 1)
+	if w, err := config.NewWatcher(); err == nil {
+		configWatcher = w
+		configWatcher.Subscribe(func(c *config.Config) {
+			select {
+			case configUpdates <- c:
+			default:
+				// previous update hasn't been consumed yet; the next change
+				// (or Refresh) will deliver a fresh one
+			}
+		})
+	}
+
 	return Model{
-		screen:    ScreenMain,
-		menuItems: []string{
-			"Launch Steam",
-			"License",
-			"Settings",
-			"Exit",
-		},
-		emailInput:   emailInput,
-		keyInput:     keyInput,
-		settings:     NewSettingsModel(),
-		messageStyle: normalStyle,
+		screen:         ScreenMain,
+		emailInput:     emailInput,
+		keyInput:       keyInput,
+		settings:       NewSettingsModel(),
+		messageStyle:   normalStyle,
+		manager:        manager,
+		licenseResult:  manager.Snapshot(),
+		licenseUpdates: updates,
+		configWatcher:  configWatcher,
+		configUpdates:  configUpdates,
+	}
+}
+
+// licenseUpdateMsg is delivered whenever the license Manager produces a new
+// Result, so views can render from m.licenseResult instead of calling
+// license.Check() themselves.
+type licenseUpdateMsg struct {
+	result license.Result
+}
+
+// waitForLicenseUpdate returns a tea.Cmd that blocks until the Manager
+// reports a new Result. Update() re-issues this Cmd after each message so
+// the model keeps listening for the life of the program.
+func waitForLicenseUpdate(updates <-chan license.Result) tea.Cmd {
+	return func() tea.Msg {
+		return licenseUpdateMsg{result: <-updates}
+	}
+}
+
+// configUpdateMsg is delivered whenever the config Watcher reloads a valid
+// Config from disk, so Settings can refresh without the user reopening it.
+type configUpdateMsg struct {
+	cfg *config.Config
+}
+
+// waitForConfigUpdate returns a tea.Cmd that blocks until the Watcher
+// reports a new Config. Update() re-issues this Cmd after each message so
+// the model keeps listening for the life of the program. Returns nil if no
+// Watcher is running.
+func waitForConfigUpdate(updates <-chan *config.Config) tea.Cmd {
+	if updates == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return configUpdateMsg{cfg: <-updates}
 	}
 }
 
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, waitForLicenseUpdate(m.licenseUpdates), waitForConfigUpdate(m.configUpdates))
 }
 
 // Update implements tea.Model
@@ -156,6 +275,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
+
+	case licenseUpdateMsg:
+		m.licenseResult = msg.result
+		return m, waitForLicenseUpdate(m.licenseUpdates)
+
+	case configUpdateMsg:
+		// Don't clobber an in-progress edit; the next reload after the user
+		// saves or cancels will reflect the on-disk change.
+		if m.settings != nil && !m.settings.editing {
+			m.settings.cfg = msg.cfg
+		}
+		return m, waitForConfigUpdate(m.configUpdates)
 	}
 	
 	// Delegate to screen-specific update
@@ -168,8 +299,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateLicenseStatus(msg)
 	case ScreenSettings:
 		return m.updateSettings(msg)
+	case ScreenLogs:
+		return m.updateLogs(msg)
+	case ScreenReport:
+		return m.updateReport(msg)
+	case ScreenUninstall:
+		return m.updateUninstall(msg)
+	case ScreenGameLibrary:
+		return m.updateGameLibrary(msg)
+	case ScreenSystemInfo:
+		return m.updateSystemInfo(msg)
 	}
-	
+
 	return m, nil
 }
 
@@ -188,8 +329,18 @@ func (m Model) View() string {
 		return m.viewLicenseStatus()
 	case ScreenSettings:
 		return m.viewSettings()
+	case ScreenLogs:
+		return m.viewLogs()
+	case ScreenReport:
+		return m.viewReport()
+	case ScreenUninstall:
+		return m.viewUninstall()
+	case ScreenGameLibrary:
+		return m.viewGameLibrary()
+	case ScreenSystemInfo:
+		return m.viewSystemInfo()
 	}
-	
+
 	return ""
 }
 
@@ -201,26 +352,34 @@ func (m Model) ShouldLaunchSteam() bool {
 // Run starts the TUI
 func Run() (launchSteam bool, err error) {
 	m := NewModel()
+	defer m.manager.Stop()
+	if m.configWatcher != nil {
+		defer m.configWatcher.Stop()
+	}
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	
+
 	finalModel, err := p.Run()
 	if err != nil {
 		return false, err
 	}
-	
+
 	if fm, ok := finalModel.(Model); ok {
 		return fm.ShouldLaunchSteam(), nil
 	}
-	
+
 	return false, nil
 }
 
 // RunLicensePrompt shows the license entry screen directly
 func RunLicensePrompt() error {
 	m := NewModel()
+	defer m.manager.Stop()
+	if m.configWatcher != nil {
+		defer m.configWatcher.Stop()
+	}
 	m.screen = ScreenLicenseEntry
 	m.emailInput.Focus()
-	
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
@@ -0,0 +1,402 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/wattfource/wizado/internal/config"
+	"github.com/wattfource/wizado/internal/launcher/steamapps"
+)
+
+// GameLibraryModel lists installed Steam titles (parsed from
+// libraryfolders.vdf and each title's appmanifest_*.acf) and lets the user
+// pick one to launch directly, or edit its per-game profile first.
+type GameLibraryModel struct {
+	games    []steamapps.Game
+	filtered []steamapps.Game
+	cursor   int
+
+	search    textinput.Model
+	searching bool
+
+	// profileOpen is true while viewing/editing the profile for
+	// profileAppID; profileEditing is true while its options-select popup
+	// is open, the same split settingsScreen uses for its own popup.
+	profileOpen        bool
+	profileAppID       string
+	profileCfg         *config.Config
+	profileCursor      int
+	profileEditing     bool
+	profileEditCursor  int
+	profileEditOptions []string
+}
+
+// NewGameLibraryModel creates a new game library model.
+func NewGameLibraryModel() *GameLibraryModel {
+	search := textinput.New()
+	search.Placeholder = "search installed games..."
+	search.CharLimit = 60
+	search.Width = 30
+
+	return &GameLibraryModel{search: search}
+}
+
+// Load (re)scans the Steam library folders for installed titles.
+func (m *GameLibraryModel) Load() {
+	games, _ := steamapps.Discover()
+	m.games = games
+	m.cursor = 0
+	m.search.SetValue("")
+	m.applyFilter()
+}
+
+// applyFilter rebuilds filtered from games using the current search value.
+func (m *GameLibraryModel) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.search.Value()))
+	if query == "" {
+		m.filtered = m.games
+	} else {
+		m.filtered = nil
+		for _, g := range m.games {
+			if strings.Contains(strings.ToLower(g.Name), query) {
+				m.filtered = append(m.filtered, g)
+			}
+		}
+	}
+	if m.cursor > len(m.filtered) {
+		m.cursor = 0
+	}
+}
+
+var gameProfileFields = []string{
+	"Resolution",
+	"FSR Upscaling",
+	"Frame Limit",
+	"VRR/Adaptive Sync",
+	"MangoHUD",
+	"─────────────",
+	"Save & Launch",
+	"Save",
+	"Cancel",
+}
+
+func (m Model) updateGameLibrary(msg tea.Msg) (tea.Model, tea.Cmd) {
+	lib := m.gameLibrary
+
+	if lib.profileEditing {
+		return m.updateGameProfileEdit(msg)
+	}
+	if lib.profileOpen {
+		return m.updateGameProfile(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if lib.searching {
+			switch msg.String() {
+			case "enter", "esc":
+				lib.searching = false
+				lib.search.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			lib.search, cmd = lib.search.Update(msg)
+			lib.applyFilter()
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "/":
+			lib.searching = true
+			lib.search.Focus()
+			return m, textinput.Blink
+		case "up", "k":
+			if lib.cursor > 0 {
+				lib.cursor--
+			}
+		case "down", "j":
+			if lib.cursor < len(lib.filtered) {
+				lib.cursor++
+			}
+		case "enter", " ":
+			return m.selectGameLibraryItem()
+		}
+	}
+	return m, nil
+}
+
+// selectGameLibraryItem handles the plain "Launch Steam" entry (cursor 0)
+// or opens the profile screen for the selected game.
+func (m Model) selectGameLibraryItem() (tea.Model, tea.Cmd) {
+	lib := m.gameLibrary
+	if lib.cursor == 0 {
+		os.Unsetenv("WIZADO_APPID")
+		m.launchSteam = true
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	game := lib.filtered[lib.cursor-1]
+	cfg, err := config.LoadMerged()
+	if err != nil {
+		cfg = config.Default()
+	}
+	profiled, err := config.LoadProfile(cfg, game.AppID)
+	if err != nil {
+		profiled = cfg
+	}
+
+	lib.profileAppID = game.AppID
+	lib.profileCfg = profiled
+	lib.profileCursor = 0
+	lib.profileOpen = true
+	return m, nil
+}
+
+func (m Model) updateGameProfile(msg tea.Msg) (tea.Model, tea.Cmd) {
+	lib := m.gameLibrary
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if lib.profileCursor > 0 {
+				lib.profileCursor--
+				if lib.profileCursor == 5 {
+					lib.profileCursor--
+				}
+			}
+		case "down", "j":
+			if lib.profileCursor < len(gameProfileFields)-1 {
+				lib.profileCursor++
+				if lib.profileCursor == 5 {
+					lib.profileCursor++
+				}
+			}
+		case "enter", " ":
+			return m.selectGameProfileItem()
+		case "esc":
+			lib.profileOpen = false
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m Model) selectGameProfileItem() (tea.Model, tea.Cmd) {
+	lib := m.gameLibrary
+	switch lib.profileCursor {
+	case 0: // Resolution
+		lib.profileEditing = true
+		lib.profileEditOptions = []string{"auto", "1920x1080", "2560x1440", "3840x2160"}
+		lib.profileEditCursor = 0
+	case 1: // FSR
+		lib.profileEditing = true
+		lib.profileEditOptions = config.FSROptions()
+		lib.profileEditCursor = 0
+	case 2: // Frame Limit
+		lib.profileEditing = true
+		lib.profileEditOptions = make([]string, len(config.FrameLimitOptions()))
+		for i, v := range config.FrameLimitOptions() {
+			if v == 0 {
+				lib.profileEditOptions[i] = "unlimited"
+			} else {
+				lib.profileEditOptions[i] = fmt.Sprintf("%d", v)
+			}
+		}
+		lib.profileEditCursor = 0
+	case 3: // VRR
+		lib.profileEditing = true
+		lib.profileEditOptions = []string{"off", "on"}
+		lib.profileEditCursor = 0
+	case 4: // MangoHUD
+		lib.profileEditing = true
+		lib.profileEditOptions = []string{"off", "on"}
+		lib.profileEditCursor = 0
+	case 6: // Save & Launch
+		config.SaveProfile(lib.profileAppID, lib.profileCfg)
+		os.Setenv("WIZADO_APPID", lib.profileAppID)
+		lib.profileOpen = false
+		m.launchSteam = true
+		m.quitting = true
+		return m, tea.Quit
+	case 7: // Save
+		config.SaveProfile(lib.profileAppID, lib.profileCfg)
+		lib.profileOpen = false
+		return m, nil
+	case 8: // Cancel
+		lib.profileOpen = false
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) updateGameProfileEdit(msg tea.Msg) (tea.Model, tea.Cmd) {
+	lib := m.gameLibrary
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if lib.profileEditCursor > 0 {
+				lib.profileEditCursor--
+			}
+		case "down", "j":
+			if lib.profileEditCursor < len(lib.profileEditOptions)-1 {
+				lib.profileEditCursor++
+			}
+		case "enter", " ":
+			m.applyGameProfileEdit()
+			lib.profileEditing = false
+			return m, nil
+		case "esc":
+			lib.profileEditing = false
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) applyGameProfileEdit() {
+	lib := m.gameLibrary
+	selected := lib.profileEditOptions[lib.profileEditCursor]
+
+	switch lib.profileCursor {
+	case 0: // Resolution
+		lib.profileCfg.Resolution = selected
+	case 1: // FSR
+		lib.profileCfg.FSR = selected
+	case 2: // Frame Limit
+		if selected == "unlimited" {
+			lib.profileCfg.FrameLimit = 0
+		} else {
+			fmt.Sscanf(selected, "%d", &lib.profileCfg.FrameLimit)
+		}
+	case 3: // VRR
+		lib.profileCfg.VRR = selected == "on"
+	case 4: // MangoHUD
+		lib.profileCfg.MangoHUD = selected == "on"
+	}
+}
+
+func (m Model) viewGameLibrary() string {
+	lib := m.gameLibrary
+	if lib.profileOpen {
+		return m.viewGameProfile()
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Game Library"))
+	b.WriteString("\n\n")
+
+	if lib.searching {
+		b.WriteString("Search: " + lib.search.View() + "\n\n")
+	} else if lib.search.Value() != "" {
+		b.WriteString(subtitleStyle.Render("Filter: "+lib.search.Value()) + "\n\n")
+	}
+
+	items := make([]string, 0, len(lib.filtered)+1)
+	items = append(items, "Launch Steam")
+	for _, g := range lib.filtered {
+		items = append(items, g.Name)
+	}
+
+	for i, label := range items {
+		cursor := "  "
+		style := normalStyle
+		if i == lib.cursor {
+			cursor = "▸ "
+			style = selectedStyle
+		}
+		b.WriteString(cursor + style.Render(label) + "\n")
+	}
+
+	if len(lib.games) == 0 {
+		b.WriteString(subtitleStyle.Render("\nNo installed Steam titles found") + "\n")
+	} else if len(lib.filtered) == 0 {
+		b.WriteString(warningStyle.Render("\nNo games match your search") + "\n")
+	}
+
+	b.WriteString(helpStyle.Render("\n↑/↓: navigate • enter: select • /: search • q: back"))
+
+	return boxStyle.Render(b.String())
+}
+
+func (m Model) viewGameProfile() string {
+	lib := m.gameLibrary
+	var b strings.Builder
+
+	title := lib.profileAppID
+	for _, g := range lib.games {
+		if g.AppID == lib.profileAppID {
+			title = g.Name
+			break
+		}
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	cfg := lib.profileCfg
+	values := []string{
+		cfg.Resolution,
+		cfg.FSR,
+		fmt.Sprintf("%d", cfg.FrameLimit),
+		boolToOnOff(cfg.VRR),
+		boolToOnOff(cfg.MangoHUD),
+		"",
+		"",
+		"",
+		"",
+	}
+
+	for i, field := range gameProfileFields {
+		cursor := "  "
+		style := normalStyle
+
+		if i == lib.profileCursor {
+			cursor = "▸ "
+			style = selectedStyle
+		}
+
+		if i == 5 {
+			b.WriteString(subtitleStyle.Render("  "+field) + "\n")
+			continue
+		}
+
+		if i < 5 {
+			line := fmt.Sprintf("%s%-18s %s", cursor, field+":", values[i])
+			b.WriteString(style.Render(line) + "\n")
+		} else {
+			b.WriteString(cursor + style.Render(field) + "\n")
+		}
+	}
+
+	if lib.profileEditing {
+		b.WriteString("\n")
+		b.WriteString(boxStyle.Render(m.viewGameProfileEditPopup()))
+	}
+
+	b.WriteString(helpStyle.Render("\n↑/↓: navigate • enter: edit • esc: back"))
+
+	return boxStyle.Render(b.String())
+}
+
+func (m Model) viewGameProfileEditPopup() string {
+	lib := m.gameLibrary
+	var b strings.Builder
+
+	b.WriteString("Select value:\n\n")
+
+	for i, opt := range lib.profileEditOptions {
+		cursor := "  "
+		style := normalStyle
+		if i == lib.profileEditCursor {
+			cursor = "▸ "
+			style = selectedStyle
+		}
+		b.WriteString(cursor + style.Render(opt) + "\n")
+	}
+
+	return b.String()
+}
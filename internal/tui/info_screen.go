@@ -82,9 +82,11 @@ func (m Model) viewSystemInfo() string {
 	b.WriteString(sectionStyle.Render("Hardware"))
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("CPU:"), valueStyle.Render(info.CPU.Model)))
-	b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("GPU:"), valueStyle.Render(info.GPU.Primary)))
-	if info.GPU.DriverVersion != "" {
-		b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Driver:"), valueStyle.Render(info.GPU.DriverVersion)))
+	if gpu := info.GPU.Primary(); gpu != nil {
+		b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("GPU:"), valueStyle.Render(gpu.Name)))
+		if gpu.DriverVersion != "" {
+			b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Driver:"), valueStyle.Render(gpu.DriverVersion)))
+		}
 	}
 	b.WriteString(fmt.Sprintf("  %s %d GiB\n", labelStyle.Render("RAM:"), info.Memory.TotalMiB/1024))
 	if info.Display.Primary.Width > 0 {
@@ -160,13 +162,16 @@ func (m Model) viewSystemInfo() string {
 	b.WriteString(sectionStyle.Render("Network"))
 	b.WriteString("\n")
 	if info.Network.HasInternet {
-		connType := info.Network.ConnectionType
-		if info.Network.SSID != "" {
-			connType = "WiFi: " + info.Network.SSID
+		connType := "unknown"
+		if primary := info.Network.Primary(); primary != nil {
+			connType = primary.Type
+			if primary.SSID != "" {
+				connType = "WiFi: " + primary.SSID
+			}
 		}
 		b.WriteString(fmt.Sprintf("  %s %s\n", successStyle.Render("✓"), valueStyle.Render("Internet: "+connType)))
 	} else {
-		b.WriteString(fmt.Sprintf("  %s %s\n", errorStyle.Render("✗"), "Internet: not connected"))
+		b.WriteString(fmt.Sprintf("  %s %s\n", errorStyle.Render("✗"), "Internet: "+info.Network.Status))
 	}
 	b.WriteString("\n")
 
@@ -57,9 +57,12 @@ func (m Model) updateLicenseEntry(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		
 		if msg.result.Success {
-			m.message = fmt.Sprintf("✓ License activated! (%d/%d slots used)", 
+			m.message = fmt.Sprintf("✓ License activated! (%d/%d slots used)",
 				msg.result.SlotsUsed, msg.result.SlotsTotal)
 			m.messageStyle = successStyle
+			// Force the Manager to re-check now instead of waiting for its
+			// next tick, so the cached result reflects the new license
+			m.manager.Refresh()
 			// Switch to main screen after short delay
 			m.screen = ScreenMain
 			return m, nil
@@ -130,6 +133,7 @@ func (m Model) updateLicenseStatus(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "c":
 			// Clear license
 			license.Clear()
+			m.manager.Refresh()
 			m.screen = ScreenMain
 			return m, nil
 		case "r":
@@ -151,24 +155,31 @@ func (m Model) viewLicenseStatus() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 	
-	// Status
-	result := license.Check()
+	// Status, from the Manager's cache
+	result := m.licenseResult
 	statusLine := m.formatLicenseStatus(result.Status)
 	b.WriteString(fmt.Sprintf("Status: %s\n", statusLine))
 	
 	// Email
 	if result.License != nil && result.License.Email != "" {
 		b.WriteString(fmt.Sprintf("Email: %s\n", result.License.Email))
-		
+
 		// Masked key
 		key := result.License.Key
 		if len(key) > 8 {
 			masked := key[:4] + "****" + key[len(key)-4:]
 			b.WriteString(fmt.Sprintf("Key: %s\n", masked))
 		}
-		
+
+		if result.License.Tier != "" {
+			b.WriteString(fmt.Sprintf("Tier: %s\n", result.License.Tier))
+		}
+		if !result.License.ExpiresAt.IsZero() {
+			b.WriteString(fmt.Sprintf("Expires: %s\n", result.License.ExpiresAt.Format("2006-01-02")))
+		}
+
 		// Last verified
-		b.WriteString(fmt.Sprintf("Last Verified: %s\n", 
+		b.WriteString(fmt.Sprintf("Last Verified: %s\n",
 			result.License.LastVerified.Format("2006-01-02 15:04")))
 	}
 	
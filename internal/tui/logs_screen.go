@@ -1,77 +1,336 @@
 package tui
 
 import (
+	"bufio"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/wattfource/wizado/internal/logging"
+)
+
+// ringSize bounds how many parsed entries LogsModel keeps in memory, so an
+// hours-long session doesn't grow the log pane unbounded.
+const ringSize = 2000
+
+// logVisibleLines is how many lines of the viewport are shown at once.
+const logVisibleLines = 15
+
+// LevelFilter narrows which severities are displayed, cycled with 'l'.
+type LevelFilter int
+
+const (
+	FilterAll LevelFilter = iota
+	FilterWarn
+	FilterError
 )
 
-// LogsModel holds logs for display
+func (f LevelFilter) String() string {
+	switch f {
+	case FilterWarn:
+		return "WARN"
+	case FilterError:
+		return "ERROR"
+	default:
+		return "ALL"
+	}
+}
+
+// allows reports whether level passes this filter.
+func (f LevelFilter) allows(level string) bool {
+	switch f {
+	case FilterWarn:
+		return level == "WARN" || level == "ERROR"
+	case FilterError:
+		return level == "ERROR"
+	default:
+		return true
+	}
+}
+
+// LogsModel holds a structured, followable view over wizado's JSON-lines
+// logs, replacing the old approach of slicing the plain-text tail at a
+// fixed byte width.
 type LogsModel struct {
-	lines      []string
-	scroll     int
-	maxLines   int
-	logType    string // "main" or "session"
-	loading    bool
+	entries []logging.Entry // ring buffer, oldest first, capped at ringSize
+	logType string          // "main" or "session"
+	filter  LevelFilter
+
+	viewport viewport.Model
+	width    int
+	height   int
+
+	// follow mode tails new lines as they're written instead of showing a
+	// one-time snapshot
+	following bool
+	watcher   *fsnotify.Watcher
+	offset    int64 // byte offset already consumed from the log file
+
+	// search
+	searching   bool
+	searchInput textinput.Model
+	searchRe    *regexp.Regexp
+
+	loading bool
+	notice  string // e.g. "No logs found: <path>"
 }
 
+// logAppendedMsg is delivered when fsnotify reports the watched log file
+// changed; the handler re-reads from m.offset rather than the whole file.
+type logAppendedMsg struct{}
+
+// logWatchErrMsg is delivered if the fsnotify watcher itself fails.
+type logWatchErrMsg struct{ err error }
+
 // NewLogsModel creates a new logs model
 func NewLogsModel() *LogsModel {
+	search := textinput.New()
+	search.Placeholder = "search logs"
+	search.CharLimit = 100
+	search.Width = 40
+
+	vp := viewport.New(60, logVisibleLines)
+
 	return &LogsModel{
-		maxLines: 50,
-		logType:  "main",
-		loading:  true,
+		logType:     "main",
+		loading:     true,
+		viewport:    vp,
+		width:       60,
+		height:      logVisibleLines,
+		searchInput: search,
 	}
 }
 
-// Load reads log files
-func (m *LogsModel) Load() {
+func (m *LogsModel) logPath() string {
 	home, _ := os.UserHomeDir()
-	var logPath string
-	
 	if m.logType == "session" {
-		logPath = filepath.Join(home, ".cache", "wizado", "latest-session.log")
-	} else {
-		logPath = filepath.Join(home, ".cache", "wizado", "wizado.log")
+		return filepath.Join(home, ".cache", "wizado", "latest-session.log")
 	}
-	
-	data, err := os.ReadFile(logPath)
+	return filepath.Join(home, ".cache", "wizado", "wizado.log")
+}
+
+// Load reads the active log file from scratch, parsing each JSON line into
+// an Entry and keeping only the most recent ringSize.
+func (m *LogsModel) Load() {
+	m.loading = false
+	m.notice = ""
+	m.entries = nil
+	m.offset = 0
+
+	path := m.logPath()
+	f, err := os.Open(path)
 	if err != nil {
-		m.lines = []string{"No logs found: " + logPath}
-		m.loading = false
+		m.notice = "No logs found: " + path
+		m.renderContent()
 		return
 	}
-	
-	allLines := strings.Split(string(data), "\n")
-	
-	// Get last N lines
-	start := 0
-	if len(allLines) > m.maxLines {
-		start = len(allLines) - m.maxLines
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m.appendLine(scanner.Bytes())
 	}
-	m.lines = allLines[start:]
-	m.loading = false
+
+	if stat, err := f.Stat(); err == nil {
+		m.offset = stat.Size()
+	}
+
+	m.renderContent()
+}
+
+// appendLine parses one JSON log line into the ring buffer, tolerating
+// stray non-JSON lines (e.g. a panic trace) by keeping them as a bare
+// message.
+func (m *LogsModel) appendLine(line []byte) {
+	if len(line) == 0 {
+		return
+	}
+
+	var entry logging.Entry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		entry = logging.Entry{Level: "INFO", Message: string(line)}
+	}
+
+	m.entries = append(m.entries, entry)
+	if len(m.entries) > ringSize {
+		m.entries = m.entries[len(m.entries)-ringSize:]
+	}
+}
+
+// readAppended reads any bytes written to the log file since m.offset and
+// parses the new lines, used by follow mode.
+func (m *LogsModel) readAppended() {
+	f, err := os.Open(m.logPath())
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil || stat.Size() < m.offset {
+		// file was truncated/rotated out from under us; re-load from scratch
+		m.Load()
+		return
+	}
+	if stat.Size() == m.offset {
+		return
+	}
+
+	if _, err := f.Seek(m.offset, 0); err != nil {
+		return
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m.appendLine(scanner.Bytes())
+	}
+	m.offset = stat.Size()
+
+	m.renderContent()
+	if m.following {
+		m.viewport.GotoBottom()
+	}
+}
+
+// startFollowing opens an fsnotify watch on the active log file and returns
+// the tea.Cmd that waits for the first event.
+func (m *LogsModel) startFollowing() tea.Cmd {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return func() tea.Msg { return logWatchErrMsg{err: err} }
+	}
+	if err := watcher.Add(m.logPath()); err != nil {
+		watcher.Close()
+		return func() tea.Msg { return logWatchErrMsg{err: err} }
+	}
+
+	m.watcher = watcher
+	return waitForLogEvent(watcher)
+}
+
+// stopFollowing closes the fsnotify watch, if one is open.
+func (m *LogsModel) stopFollowing() {
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
+	}
+}
+
+// waitForLogEvent blocks on the watcher until a write/create event arrives,
+// then returns a Cmd the caller re-issues to keep listening.
+func waitForLogEvent(watcher *fsnotify.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return nil
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					return logAppendedMsg{}
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return nil
+				}
+				return logWatchErrMsg{err: err}
+			}
+		}
+	}
+}
+
+// visibleEntries applies the active level filter and search pattern.
+func (m *LogsModel) visibleEntries() []logging.Entry {
+	var out []logging.Entry
+	for _, e := range m.entries {
+		if !m.filter.allows(e.Level) {
+			continue
+		}
+		if m.searchRe != nil && !m.searchRe.MatchString(e.Message) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// renderContent rebuilds the viewport's content from the current filter and
+// search state. Long lines wrap (via the style's Width) instead of being
+// sliced at a fixed byte offset, which used to panic on multi-byte UTF-8.
+func (m *LogsModel) renderContent() {
+	lineStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245")).
+		Width(m.width)
+
+	var b strings.Builder
+	for _, e := range m.visibleEntries() {
+		text := formatLogLine(e)
+		if m.searchRe != nil {
+			text = highlightMatches(text, m.searchRe)
+		}
+
+		switch e.Level {
+		case "ERROR":
+			b.WriteString(errorStyle.Width(m.width).Render(text))
+		case "WARN":
+			b.WriteString(warningStyle.Width(m.width).Render(text))
+		default:
+			b.WriteString(lineStyle.Render(text))
+		}
+		b.WriteString("\n")
+	}
+
+	m.viewport.SetContent(b.String())
+}
+
+// formatLogLine renders one Entry as "HH:MM:SS [LEVEL] [component] message
+// key=value ...", mirroring logging.Logger's plain-text format.
+func formatLogLine(e logging.Entry) string {
+	ts := e.Timestamp.Format("15:04:05")
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%s]", ts, e.Level)
+	if e.Component != "" {
+		fmt.Fprintf(&b, " [%s]", e.Component)
+	}
+	fmt.Fprintf(&b, " %s", e.Message)
+	for k, v := range e.Fields {
+		fmt.Fprintf(&b, " %s=%v", k, v)
+	}
+	return b.String()
+}
+
+// highlightMatches wraps each regexp match in a reverse-video style so
+// search hits stand out in the viewport.
+func highlightMatches(text string, re *regexp.Regexp) string {
+	matchStyle := lipgloss.NewStyle().Reverse(true)
+	return re.ReplaceAllStringFunc(text, func(match string) string {
+		return matchStyle.Render(match)
+	})
 }
 
 func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.logsModel.searching {
+		return m.updateLogSearch(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "up", "k":
-			if m.logsModel.scroll > 0 {
-				m.logsModel.scroll--
-			}
+			m.logsModel.viewport.LineUp(1)
 		case "down", "j":
-			maxScroll := len(m.logsModel.lines) - 20 // visible lines
-			if maxScroll < 0 {
-				maxScroll = 0
-			}
-			if m.logsModel.scroll < maxScroll {
-				m.logsModel.scroll++
-			}
+			m.logsModel.viewport.LineDown(1)
 		case "tab":
 			// Toggle between main and session logs
 			if m.logsModel.logType == "main" {
@@ -79,16 +338,72 @@ func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.logsModel.logType = "main"
 			}
-			m.logsModel.scroll = 0
 			m.logsModel.Load()
+		case "l":
+			m.logsModel.filter = (m.logsModel.filter + 1) % 3
+			m.logsModel.renderContent()
+		case "f":
+			m.logsModel.following = !m.logsModel.following
+			if m.logsModel.following {
+				m.logsModel.viewport.GotoBottom()
+				return m, m.logsModel.startFollowing()
+			}
+			m.logsModel.stopFollowing()
+		case "/":
+			m.logsModel.searching = true
+			m.logsModel.searchInput.Focus()
+			return m, textinput.Blink
 		case "esc", "q":
+			m.logsModel.stopFollowing()
 			m.screen = ScreenMain
 			return m, nil
 		}
+
+	case logAppendedMsg:
+		m.logsModel.readAppended()
+		if m.logsModel.following {
+			return m, waitForLogEvent(m.logsModel.watcher)
+		}
+		return m, nil
+
+	case logWatchErrMsg:
+		m.logsModel.following = false
+		m.logsModel.notice = fmt.Sprintf("Stopped following: %v", msg.err)
+		return m, nil
 	}
+
 	return m, nil
 }
 
+func (m Model) updateLogSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "enter":
+			pattern := m.logsModel.searchInput.Value()
+			if pattern == "" {
+				m.logsModel.searchRe = nil
+			} else if re, err := regexp.Compile(pattern); err == nil {
+				m.logsModel.searchRe = re
+			} else {
+				// not a valid regex - fall back to a literal substring match
+				m.logsModel.searchRe = regexp.MustCompile(regexp.QuoteMeta(pattern))
+			}
+			m.logsModel.searching = false
+			m.logsModel.searchInput.Blur()
+			m.logsModel.renderContent()
+			return m, nil
+		case "esc":
+			m.logsModel.searching = false
+			m.logsModel.searchInput.Blur()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.logsModel.searchInput, cmd = m.logsModel.searchInput.Update(msg)
+	return m, cmd
+}
+
 func (m Model) viewLogs() string {
 	var b strings.Builder
 
@@ -99,7 +414,7 @@ func (m Model) viewLogs() string {
 		Render("LOGS")
 	b.WriteString(title)
 	b.WriteString("\n")
-	
+
 	// Log type indicator
 	mainStyle := normalStyle
 	sessionStyle := normalStyle
@@ -111,6 +426,10 @@ func (m Model) viewLogs() string {
 	b.WriteString(mainStyle.Render("[Main]"))
 	b.WriteString(" ")
 	b.WriteString(sessionStyle.Render("[Session]"))
+	b.WriteString(fmt.Sprintf("  filter:%s", m.logsModel.filter))
+	if m.logsModel.following {
+		b.WriteString(successStyle.Render("  ● following"))
+	}
 	b.WriteString("\n\n")
 
 	if m.logsModel == nil || m.logsModel.loading {
@@ -118,49 +437,35 @@ func (m Model) viewLogs() string {
 		return boxStyle.Render(b.String())
 	}
 
-	if len(m.logsModel.lines) == 0 {
-		b.WriteString("No logs found.")
+	if m.logsModel.notice != "" && len(m.logsModel.entries) == 0 {
+		b.WriteString(m.logsModel.notice)
 		return boxStyle.Render(b.String())
 	}
 
-	// Display visible lines
-	logStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
-		Width(60)
-	
-	visibleLines := 15
-	start := m.logsModel.scroll
-	end := start + visibleLines
-	if end > len(m.logsModel.lines) {
-		end = len(m.logsModel.lines)
-	}
-	
-	for i := start; i < end; i++ {
-		line := m.logsModel.lines[i]
-		if len(line) > 60 {
-			line = line[:57] + "..."
-		}
-		// Color based on log level
-		if strings.Contains(line, "[ERROR]") {
-			b.WriteString(errorStyle.Render(line))
-		} else if strings.Contains(line, "[WARN]") {
-			b.WriteString(warningStyle.Render(line))
-		} else {
-			b.WriteString(logStyle.Render(line))
+	if len(m.logsModel.visibleEntries()) == 0 {
+		b.WriteString("No log entries match the current filter/search.")
+	} else {
+		b.WriteString(m.logsModel.viewport.View())
+
+		total := len(m.logsModel.visibleEntries())
+		shown := m.logsModel.viewport.Height
+		if shown > total {
+			shown = total
 		}
+		start := m.logsModel.viewport.YOffset + 1
+		end := m.logsModel.viewport.YOffset + shown
+		scrollInfo := lipgloss.NewStyle().Foreground(mutedColor).Render(
+			fmt.Sprintf(" (showing %d-%d of %d)", start, end, total))
 		b.WriteString("\n")
+		b.WriteString(scrollInfo)
 	}
 
-	// Scroll indicator
-	if len(m.logsModel.lines) > visibleLines {
-		scrollInfo := lipgloss.NewStyle().Foreground(mutedColor).Render(
-			" (showing " + string(rune('0'+start)) + "-" + string(rune('0'+end)) + " of " + string(rune('0'+len(m.logsModel.lines))) + ")")
-		b.WriteString(scrollInfo)
+	if m.logsModel.searching {
+		b.WriteString("\n\nSearch: " + m.logsModel.searchInput.View())
 	}
 
 	// Help
-	b.WriteString(helpStyle.Render("\n↑/↓: scroll • tab: switch logs • esc: back"))
+	b.WriteString(helpStyle.Render("\n↑/↓: scroll • tab: switch logs • f: follow • l: filter • /: search • esc: back"))
 
 	return boxStyle.Render(b.String())
 }
-
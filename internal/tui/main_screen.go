@@ -18,7 +18,7 @@ func (m Model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor--
 			}
 		case "down", "j":
-			if m.cursor < len(m.menuItems)-1 {
+			if m.cursor < len(menuItems)-1 {
 				m.cursor++
 			}
 		case "enter", " ":
@@ -29,14 +29,24 @@ func (m Model) updateMain(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) selectMenuItem() (tea.Model, tea.Cmd) {
+	item := menuItems[m.cursor]
+	if item.RequiredFeature != "" && !m.licenseResult.HasFeature(item.RequiredFeature) {
+		m.message = item.Label + " requires an upgraded license"
+		m.messageStyle = warningStyle
+		return m, nil
+	}
+
 	switch m.cursor {
 	case 0: // Launch Steam
-		// Check license first
-		result := license.Check()
+		// Check license first, from the Manager's cache
+		result := m.licenseResult
 		if result.Status == license.StatusValid || result.Status == license.StatusOfflineGrace {
-			m.launchSteam = true
-			m.quitting = true
-			return m, tea.Quit
+			m.screen = ScreenGameLibrary
+			if m.gameLibrary == nil {
+				m.gameLibrary = NewGameLibraryModel()
+			}
+			m.gameLibrary.Load()
+			return m, nil
 		}
 		// No valid license, show license entry
 		m.screen = ScreenLicenseEntry
@@ -44,9 +54,9 @@ func (m Model) selectMenuItem() (tea.Model, tea.Cmd) {
 		m.message = "License required to launch Steam"
 		m.messageStyle = warningStyle
 		return m, nil
-		
+
 	case 1: // License
-		result := license.Check()
+		result := m.licenseResult
 		if result.Status == license.StatusValid || result.Status == license.StatusOfflineGrace {
 			m.screen = ScreenLicenseStatus
 			m.licenseStatus = string(result.Status)
@@ -79,14 +89,22 @@ func (m Model) selectMenuItem() (tea.Model, tea.Cmd) {
 		m.logsModel.Load()
 		return m, nil
 		
-	case 5: // Uninstall
+	case 5: // Report
+		m.screen = ScreenReport
+		if m.reportModel == nil {
+			m.reportModel = NewReportModel()
+		}
+		m.reportModel.Load()
+		return m, nil
+
+	case 6: // Uninstall
 		m.screen = ScreenUninstall
 		if m.uninstallModel == nil {
 			m.uninstallModel = NewUninstallModel()
 		}
 		return m, nil
-		
-	case 6: // Exit
+
+	case 7: // Exit
 		m.quitting = true
 		return m, tea.Quit
 	}
@@ -109,21 +127,29 @@ func (m Model) viewMain() string {
 	b.WriteString(subtitle)
 	b.WriteString("\n\n")
 	
-	// License status indicator
-	result := license.Check()
+	// License status indicator, read from the Manager's cache rather than
+	// re-checking on every render
+	result := m.licenseResult
 	statusLine := m.formatLicenseStatus(result.Status)
 	b.WriteString(statusLine)
 	b.WriteString("\n\n")
 	
 	// Menu
-	for i, item := range m.menuItems {
+	for i, item := range menuItems {
 		cursor := "  "
 		style := normalStyle
+		label := item.Label
+		if item.RequiredFeature != "" && !result.HasFeature(item.RequiredFeature) {
+			style = lipgloss.NewStyle().Foreground(mutedColor)
+			label += " (locked)"
+		}
 		if i == m.cursor {
 			cursor = "▸ "
-			style = selectedStyle
+			if style.GetForeground() == normalStyle.GetForeground() {
+				style = selectedStyle
+			}
 		}
-		b.WriteString(cursor + style.Render(item) + "\n")
+		b.WriteString(cursor + style.Render(label) + "\n")
 	}
 	
 	// Help
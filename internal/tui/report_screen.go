@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/wattfource/wizado/internal/telemetry"
+)
+
+// reportPeriod is the fixed lookback GenerateReport is called with, matching
+// the CLI's own --since default.
+const reportPeriod = 7 * 24 * time.Hour
+
+// ReportModel holds the local telemetry rollup shown on the Report screen.
+type ReportModel struct {
+	report  *telemetry.Report
+	loading bool
+	err     error
+}
+
+// NewReportModel creates a new report model
+func NewReportModel() *ReportModel {
+	return &ReportModel{
+		loading: true,
+	}
+}
+
+// Load generates the report for the default lookback period.
+func (m *ReportModel) Load() {
+	report, err := telemetry.Default().GenerateReport(reportPeriod)
+	m.report = report
+	m.err = err
+	m.loading = false
+}
+
+func (m Model) updateReport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			m.screen = ScreenMain
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewReport() string {
+	var b strings.Builder
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(primaryColor).
+		Render("TELEMETRY REPORT")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if m.reportModel == nil || m.reportModel.loading {
+		b.WriteString("Generating report...")
+		b.WriteString(helpStyle.Render("\n\nesc: back"))
+		return boxStyle.Render(b.String())
+	}
+
+	if m.reportModel.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Failed to generate report: %v", m.reportModel.err)))
+		b.WriteString(helpStyle.Render("\n\nesc: back"))
+		return boxStyle.Render(b.String())
+	}
+
+	report := m.reportModel.report
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(secondaryColor)
+	labelStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+
+	b.WriteString(sectionStyle.Render(fmt.Sprintf("Sessions (last %s)", report.Period)))
+	b.WriteString("\n")
+	b.WriteString(reportRow(labelStyle, valueStyle, "Total", fmt.Sprintf("%d", report.TotalSessions)))
+	b.WriteString(reportRow(labelStyle, valueStyle, "Mean duration", fmt.Sprintf("%.0fs", report.MeanDurationSec)))
+	b.WriteString(reportRow(labelStyle, valueStyle, "Median duration", fmt.Sprintf("%.0fs", report.MedianDurationSec)))
+	b.WriteString(reportRow(labelStyle, valueStyle, "P95 duration", fmt.Sprintf("%.0fs", report.P95DurationSec)))
+	b.WriteString("\n")
+
+	b.WriteString(sectionStyle.Render("Crashes by exit code"))
+	b.WriteString("\n")
+	if len(report.CrashesByExitCode) == 0 {
+		b.WriteString(labelStyle.Render("  none recorded"))
+		b.WriteString("\n")
+	} else {
+		for _, code := range sortedReportExitCodes(report.CrashesByExitCode) {
+			stat := report.CrashesByExitCode[code]
+			b.WriteString(reportRow(labelStyle, valueStyle,
+				fmt.Sprintf("Exit %d", code),
+				fmt.Sprintf("%d (%.1f%%)", stat.Count, stat.Rate*100)))
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(sectionStyle.Render("Resolution / FSR / frame limit"))
+	b.WriteString("\n")
+	for _, res := range sortedReportStringKeys(report.ResolutionDistribution) {
+		b.WriteString(reportRow(labelStyle, valueStyle, res, fmt.Sprintf("%d", report.ResolutionDistribution[res])))
+	}
+	for _, fsr := range sortedReportStringKeys(report.FSRDistribution) {
+		b.WriteString(reportRow(labelStyle, valueStyle, "FSR "+fsr, fmt.Sprintf("%d", report.FSRDistribution[fsr])))
+	}
+	for _, fl := range sortedReportFrameLimits(report.FrameLimitDistribution) {
+		b.WriteString(reportRow(labelStyle, valueStyle, fmt.Sprintf("%d fps cap", fl), fmt.Sprintf("%d", report.FrameLimitDistribution[fl])))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(sectionStyle.Render("Errors by component"))
+	b.WriteString("\n")
+	if len(report.ErrorsByComponent) == 0 {
+		b.WriteString(labelStyle.Render("  none recorded"))
+		b.WriteString("\n")
+	} else {
+		for _, comp := range sortedReportStringKeys(report.ErrorsByComponent) {
+			b.WriteString(reportRow(labelStyle, valueStyle, comp, fmt.Sprintf("%d", report.ErrorsByComponent[comp])))
+		}
+	}
+
+	b.WriteString(helpStyle.Render("\nesc: back"))
+
+	return boxStyle.Render(b.String())
+}
+
+// reportRow renders one label/value table row, padding the label column so
+// values line up regardless of label length.
+func reportRow(labelStyle, valueStyle lipgloss.Style, label, value string) string {
+	return fmt.Sprintf("  %s %s\n", labelStyle.Render(fmt.Sprintf("%-20s", label+":")), valueStyle.Render(value))
+}
+
+func sortedReportStringKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedReportExitCodes(m map[int]telemetry.ExitCodeStat) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func sortedReportFrameLimits(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -10,146 +11,276 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// uninstallPhase tracks where the user is in the uninstall flow: confirm
+// first, then pick which components to remove (and whether to dry-run),
+// then execute and show the result.
+type uninstallPhase int
+
+const (
+	uninstallPhaseConfirm uninstallPhase = iota
+	uninstallPhaseSelect
+	uninstallPhaseDone
+)
+
+// uninstallTarget is one individually toggleable removal target.
+type uninstallTarget struct {
+	label   string
+	enabled bool
+	remove  func(dryRun bool) string // returns a one-line report of what happened/would happen
+}
+
 // UninstallModel holds uninstall state
 type UninstallModel struct {
-	confirmed   bool
-	executing   bool
-	done        bool
-	result      string
-	cursor      int
+	phase     uninstallPhase
+	cursor    int // Yes/No cursor on the confirm phase
+
+	targets   []uninstallTarget
+	selCursor int // row index on the select phase: targets..., then dry-run toggle, then "Run"
+	dryRun    bool
+
+	executing bool
+	done      bool
+	result    string
 }
 
 // NewUninstallModel creates a new uninstall model
 func NewUninstallModel() *UninstallModel {
 	return &UninstallModel{
-		cursor: 1, // Default to "No"
+		cursor:  1, // Default to "No"
+		targets: defaultUninstallTargets(),
 	}
 }
 
-func (m Model) updateUninstall(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if m.uninstallModel.done {
-			// Any key returns to main
-			m.screen = ScreenMain
-			m.uninstallModel = NewUninstallModel()
-			return m, nil
-		}
-		
-		if m.uninstallModel.executing {
-			return m, nil
-		}
-		
-		switch msg.String() {
-		case "left", "h":
-			if m.uninstallModel.cursor > 0 {
-				m.uninstallModel.cursor--
+// defaultUninstallTargets lists every removal target, all enabled by
+// default. Each carries its own removal logic so the select phase can run a
+// subset (and executeUninstall only passes dry-run through to them - every
+// target's remove func decides what to do with it).
+func defaultUninstallTargets() []uninstallTarget {
+	home, _ := os.UserHomeDir()
+
+	return []uninstallTarget{
+		removeDirTarget("Configuration", filepath.Join(home, ".config", "wizado")),
+		removeDirTarget("Cache & logs", filepath.Join(home, ".cache", "wizado")),
+		removeDirTarget("Telemetry data", filepath.Join(home, ".local", "share", "wizado")),
+		removeBindingsTarget(filepath.Join(home, ".config", "hypr", "bindings.conf")),
+		removeBindingsTarget(filepath.Join(home, ".config", "hypr", "keybinds.conf")),
+		removeBindingsTarget(filepath.Join(home, ".config", "hypr", "hyprland.conf")),
+		reloadHyprlandTarget(),
+	}
+}
+
+func removeDirTarget(label, dir string) uninstallTarget {
+	return uninstallTarget{
+		label:   fmt.Sprintf("%s (%s)", label, dir),
+		enabled: true,
+		remove: func(dryRun bool) string {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				return "Not found: " + dir
+			}
+			if dryRun {
+				return "Would remove: " + dir
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				return "Warning: could not remove " + dir
+			}
+			return "Removed: " + dir
+		},
+	}
+}
+
+const bindingsStartMarker = "# Wizado - added by wizado"
+const bindingsEndMarker = "# End Wizado bindings"
+
+func removeBindingsTarget(path string) uninstallTarget {
+	return uninstallTarget{
+		label:   fmt.Sprintf("Keybindings in %s", path),
+		enabled: true,
+		remove: func(dryRun bool) string {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "Not found: " + path
+			}
+
+			content := string(data)
+			if !strings.Contains(content, bindingsStartMarker) {
+				return "No wizado bindings in: " + path
+			}
+
+			if dryRun {
+				return "Would remove keybindings from: " + path
+			}
+
+			startIdx := strings.Index(content, bindingsStartMarker)
+			endIdx := strings.Index(content, bindingsEndMarker)
+			if startIdx == -1 || endIdx == -1 {
+				return "No wizado bindings in: " + path
+			}
+			if startIdx > 0 && content[startIdx-1] == '\n' {
+				startIdx--
 			}
-		case "right", "l":
-			if m.uninstallModel.cursor < 1 {
-				m.uninstallModel.cursor++
+			content = content[:startIdx] + content[endIdx+len(bindingsEndMarker):]
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				return "Warning: could not update " + path
 			}
-		case "enter", " ":
-			if m.uninstallModel.cursor == 0 {
-				// Yes - execute uninstall
-				m.uninstallModel.executing = true
-				m.uninstallModel.result = executeUninstall()
-				m.uninstallModel.done = true
-				m.uninstallModel.executing = false
-			} else {
-				// No - go back
-				m.screen = ScreenMain
-				m.uninstallModel = NewUninstallModel()
+			return "Removed keybindings from: " + path
+		},
+	}
+}
+
+func reloadHyprlandTarget() uninstallTarget {
+	return uninstallTarget{
+		label:   "Reload Hyprland (hyprctl reload)",
+		enabled: true,
+		remove: func(dryRun bool) string {
+			if dryRun {
+				return "Would run: hyprctl reload"
 			}
-			return m, nil
-		case "esc", "q":
+			exec.Command("hyprctl", "reload").Run()
+			return "Ran: hyprctl reload"
+		},
+	}
+}
+
+func (m Model) updateUninstall(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.uninstallModel.done {
+		// Any key returns to main
+		m.screen = ScreenMain
+		m.uninstallModel = NewUninstallModel()
+		return m, nil
+	}
+
+	if m.uninstallModel.executing {
+		return m, nil
+	}
+
+	switch m.uninstallModel.phase {
+	case uninstallPhaseConfirm:
+		return m.updateUninstallConfirm(keyMsg)
+	case uninstallPhaseSelect:
+		return m.updateUninstallSelect(keyMsg)
+	}
+	return m, nil
+}
+
+func (m Model) updateUninstallConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		if m.uninstallModel.cursor > 0 {
+			m.uninstallModel.cursor--
+		}
+	case "right", "l":
+		if m.uninstallModel.cursor < 1 {
+			m.uninstallModel.cursor++
+		}
+	case "enter", " ":
+		if m.uninstallModel.cursor == 0 {
+			// Yes - move to the component/dry-run selection phase
+			m.uninstallModel.phase = uninstallPhaseSelect
+			m.uninstallModel.selCursor = 0
+		} else {
+			// No - go back
 			m.screen = ScreenMain
 			m.uninstallModel = NewUninstallModel()
-			return m, nil
 		}
+		return m, nil
+	case "esc", "q":
+		m.screen = ScreenMain
+		m.uninstallModel = NewUninstallModel()
+		return m, nil
 	}
 	return m, nil
 }
 
-func executeUninstall() string {
-	var result strings.Builder
-	home, _ := os.UserHomeDir()
-	
-	// Remove config directory
-	configDir := filepath.Join(home, ".config", "wizado")
-	if err := os.RemoveAll(configDir); err != nil {
-		result.WriteString("Warning: could not remove config\n")
-	} else {
-		result.WriteString("Removed: " + configDir + "\n")
-	}
-	
-	// Remove cache directory
-	cacheDir := filepath.Join(home, ".cache", "wizado")
-	if err := os.RemoveAll(cacheDir); err != nil {
-		result.WriteString("Warning: could not remove cache\n")
-	} else {
-		result.WriteString("Removed: " + cacheDir + "\n")
-	}
-	
-	// Remove local data directory (telemetry)
-	dataDir := filepath.Join(home, ".local", "share", "wizado")
-	if err := os.RemoveAll(dataDir); err != nil {
-		result.WriteString("Warning: could not remove data\n")
-	} else {
-		result.WriteString("Removed: " + dataDir + "\n")
-	}
-	
-	// Remove keybindings from Hyprland config
-	bindingsPaths := []string{
-		filepath.Join(home, ".config", "hypr", "bindings.conf"),
-		filepath.Join(home, ".config", "hypr", "keybinds.conf"),
-		filepath.Join(home, ".config", "hypr", "hyprland.conf"),
-	}
-	
-	for _, path := range bindingsPaths {
-		data, err := os.ReadFile(path)
-		if err != nil {
-			continue
+// selectRowCount is the number of navigable rows on the select phase: one
+// per target, plus the dry-run toggle, plus the "Run" action.
+func (u *UninstallModel) selectRowCount() int {
+	return len(u.targets) + 2
+}
+
+func (m Model) updateUninstallSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	u := m.uninstallModel
+	lastRow := u.selectRowCount() - 1
+
+	switch msg.String() {
+	case "up", "k":
+		if u.selCursor > 0 {
+			u.selCursor--
 		}
-		
-		content := string(data)
-		if !strings.Contains(content, "# Wizado - added by wizado") {
-			continue
+	case "down", "j":
+		if u.selCursor < lastRow {
+			u.selCursor++
 		}
-		
-		// Remove wizado bindings block
-		startMarker := "# Wizado - added by wizado"
-		endMarker := "# End Wizado bindings"
-		
-		startIdx := strings.Index(content, startMarker)
-		endIdx := strings.Index(content, endMarker)
-		
-		if startIdx != -1 && endIdx != -1 {
-			// Include a newline before the block
-			if startIdx > 0 && content[startIdx-1] == '\n' {
-				startIdx--
-			}
-			content = content[:startIdx] + content[endIdx+len(endMarker):]
-			if err := os.WriteFile(path, []byte(content), 0644); err == nil {
-				result.WriteString("Removed keybindings from: " + path + "\n")
-			}
+	case " ":
+		switch {
+		case u.selCursor < len(u.targets):
+			u.targets[u.selCursor].enabled = !u.targets[u.selCursor].enabled
+		case u.selCursor == len(u.targets):
+			u.dryRun = !u.dryRun
 		}
+	case "enter":
+		switch {
+		case u.selCursor < len(u.targets):
+			u.targets[u.selCursor].enabled = !u.targets[u.selCursor].enabled
+		case u.selCursor == len(u.targets):
+			u.dryRun = !u.dryRun
+		case u.selCursor == lastRow:
+			u.executing = true
+			u.result = executeUninstall(u.targets, u.dryRun)
+			u.done = true
+			u.executing = false
+		}
+	case "esc", "q":
+		m.screen = ScreenMain
+		m.uninstallModel = NewUninstallModel()
+		return m, nil
 	}
-	
-	// Reload Hyprland
-	exec.Command("hyprctl", "reload").Run()
-	
-	result.WriteString("\nWizado configuration removed.\n")
-	result.WriteString("To fully uninstall, run:\n")
-	result.WriteString("  sudo pacman -R wizado\n")
-	
+
+	return m, nil
+}
+
+// executeUninstall runs the remove func for every enabled target, in order,
+// collecting each one's report line. Disabled targets are skipped entirely -
+// dry-run is handled per-target instead, so a target can be both skipped and
+// previewed independently.
+func executeUninstall(targets []uninstallTarget, dryRun bool) string {
+	var result strings.Builder
+
+	for _, t := range targets {
+		if !t.enabled {
+			continue
+		}
+		result.WriteString(t.remove(dryRun))
+		result.WriteString("\n")
+	}
+
+	if dryRun {
+		result.WriteString("\nDry run - nothing was changed.\n")
+	} else {
+		result.WriteString("\nWizado configuration removed.\n")
+		result.WriteString("To fully uninstall, run:\n")
+		result.WriteString("  sudo pacman -R wizado\n")
+	}
+
 	return result.String()
 }
 
 func (m Model) viewUninstall() string {
+	switch m.uninstallModel.phase {
+	case uninstallPhaseSelect:
+		return m.viewUninstallSelect()
+	default:
+		return m.viewUninstallConfirm()
+	}
+}
+
+func (m Model) viewUninstallConfirm() string {
 	var b strings.Builder
 
-	// Title
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(errorColor).
@@ -164,7 +295,7 @@ func (m Model) viewUninstall() string {
 		b.WriteString(helpStyle.Render("\nPress any key to return..."))
 		return boxStyle.Render(b.String())
 	}
-	
+
 	if m.uninstallModel.executing {
 		b.WriteString("Removing wizado configuration...")
 		return boxStyle.Render(b.String())
@@ -178,9 +309,9 @@ func (m Model) viewUninstall() string {
 		Render("This will remove:\n• Configuration (~/.config/wizado)\n• Cache & logs (~/.cache/wizado)\n• Telemetry data (~/.local/share/wizado)\n• Hyprland keybindings")
 	b.WriteString(warningBox)
 	b.WriteString("\n\n")
-	
+
 	b.WriteString("Are you sure you want to uninstall?\n\n")
-	
+
 	// Yes/No buttons
 	yesStyle := normalStyle
 	noStyle := normalStyle
@@ -206,7 +337,7 @@ func (m Model) viewUninstall() string {
 			Foreground(mutedColor).
 			Padding(0, 2)
 	}
-	
+
 	b.WriteString("  ")
 	b.WriteString(yesStyle.Render("Yes, uninstall"))
 	b.WriteString("  ")
@@ -219,3 +350,66 @@ func (m Model) viewUninstall() string {
 	return boxStyle.Render(b.String())
 }
 
+func (m Model) viewUninstallSelect() string {
+	var b strings.Builder
+	u := m.uninstallModel
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(errorColor).
+		Render("UNINSTALL WIZADO - select components")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if u.done {
+		b.WriteString(successStyle.Render("Uninstall completed:"))
+		b.WriteString("\n\n")
+		b.WriteString(u.result)
+		b.WriteString(helpStyle.Render("\nPress any key to return..."))
+		return boxStyle.Render(b.String())
+	}
+
+	if u.executing {
+		b.WriteString("Removing selected components...")
+		return boxStyle.Render(b.String())
+	}
+
+	for i, t := range u.targets {
+		b.WriteString(checkboxLine(i == u.selCursor, t.enabled, t.label))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(checkboxLine(u.selCursor == len(u.targets), u.dryRun, "Dry run (preview only, make no changes)"))
+	b.WriteString("\n\n")
+
+	runCursor := " "
+	runStyle := normalStyle
+	if u.selCursor == u.selectRowCount()-1 {
+		runCursor = "▸"
+		runStyle = selectedStyle
+	}
+	action := "Run uninstall"
+	if u.dryRun {
+		action = "Preview uninstall (dry run)"
+	}
+	b.WriteString(fmt.Sprintf("%s %s", runCursor, runStyle.Render(action)))
+
+	b.WriteString(helpStyle.Render("\n\n↑/↓: navigate • space/enter: toggle • enter on Run: execute • esc: cancel"))
+
+	return boxStyle.Render(b.String())
+}
+
+func checkboxLine(focused, checked bool, label string) string {
+	cursor := " "
+	style := normalStyle
+	if focused {
+		cursor = "▸"
+		style = selectedStyle
+	}
+	box := "[ ]"
+	if checked {
+		box = "[x]"
+	}
+	return fmt.Sprintf("%s %s %s", cursor, box, style.Render(label))
+}